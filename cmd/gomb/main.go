@@ -1,38 +1,139 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
 
 	"github.com/v4t/gomb/pkg/cartridge"
+	"github.com/v4t/gomb/pkg/cheat"
 	"github.com/v4t/gomb/pkg/emulator"
+	"github.com/v4t/gomb/pkg/hardware"
+	"github.com/v4t/gomb/pkg/loader"
+	"github.com/v4t/gomb/pkg/patch"
 )
 
 func main() {
-	if len(os.Args) != 2 {
+	if len(os.Args) > 1 && os.Args[1] == "sram" {
+		sramCommand(os.Args[2:])
+		return
+	}
+
+	entry := flag.String("entry", "", "ROM file loaded from zip archive (default: first .gb or .gbc file)")
+	patchFile := flag.String("patch", "", "IPS, UPS or BPS patch applied to ROM (default: patch file with the same name as ROM)")
+	datFile := flag.String("dat", "", "No-Intro DAT file used for identifying ROM dumps in addition to the embedded DAT")
+	overridesFile := flag.String("overrides", "", "file containing per-game overrides in addition to the embedded overrides")
+	modelName := flag.String("model", "", "hardware model: DMG, MGB, SGB, CGB or AGB (default: selected from cartridge header)")
+	bootROMFile := flag.String("boot", "", "boot ROM executed before the game, or directory containing boot ROMs of each model")
+	cheatFile := flag.String("cheats", "", "text file containing Game Genie and GameShark codes")
+	cameraImage := flag.String("camera", "", "PNG image used as Pocket Camera input")
+	flag.Parse()
+	if flag.NArg() != 1 {
 		log.Fatalf("Program requires ROM file as parameter")
 	}
-	romFile := os.Args[1]
+	romFile := flag.Arg(0)
 
-	rom, err := loadRom(romFile)
+	rom, err := loader.Load(romFile, *entry)
 	if err != nil {
 		log.Fatalf("Error when loading ROM: %v", err)
 	}
 
-	cart := cartridge.NewCartridge(rom)
+	if *patchFile == "" {
+		*patchFile, _ = patch.FindPatch(loader.ROMPath(romFile))
+	}
+	if *patchFile != "" {
+		rom, err = applyPatch(rom, *patchFile)
+		if err != nil {
+			log.Fatalf("Error when applying patch %s: %v", *patchFile, err)
+		}
+		fmt.Printf("Applied patch %s\n", *patchFile)
+	}
+
+	if *datFile != "" {
+		if err := cartridge.Database.LoadDATFile(*datFile); err != nil {
+			log.Fatalf("Error when loading DAT file: %v", err)
+		}
+	}
+	if *overridesFile != "" {
+		if err := cartridge.Database.LoadOverridesFile(*overridesFile); err != nil {
+			log.Fatalf("Error when loading overrides file: %v", err)
+		}
+	}
+
+	cart, err := cartridge.NewCartridge(rom)
+	if err != nil {
+		log.Fatalf("Error when loading cartridge: %v", err)
+	}
 	fmt.Println(cart)
 
-	gb := emulator.NewGameboy()
+	if err := cart.LoadSaveFile(cartridge.SavePath(loader.ROMPath(romFile))); err != nil {
+		log.Fatalf("Error when loading save file: %v", err)
+	}
+
+	if *cameraImage != "" {
+		source, err := cartridge.LoadImageFile(*cameraImage)
+		if err != nil {
+			log.Fatalf("Error when loading camera image: %v", err)
+		}
+		cart.SetImageSource(source)
+	}
+
+	var options []emulator.Option
+	if *modelName != "" {
+		model, err := hardware.ParseModel(*modelName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		options = append(options, emulator.WithModel(model))
+	}
+	if *bootROMFile != "" {
+		option, err := bootROMOption(*bootROMFile)
+		if err != nil {
+			log.Fatalf("Error when loading boot ROM: %v", err)
+		}
+		options = append(options, option)
+	}
+
+	gb := emulator.NewGameboy(options...)
+	if *cheatFile != "" {
+		gb.Cheats, err = cheat.LoadFile(*cheatFile)
+		if err != nil {
+			log.Fatalf("Error when loading cheats: %v", err)
+		}
+	}
 	gb.Start(cart)
+	if err := cart.Flush(); err != nil {
+		log.Fatalf("Error when writing save file: %v", err)
+	}
 	os.Exit(0)
 }
 
-func loadRom(fname string) ([]byte, error) {
+func applyPatch(rom []byte, fname string) ([]byte, error) {
 	data, err := ioutil.ReadFile(fname)
 	if err != nil {
 		return nil, err
 	}
-	return data, nil
+	return patch.Apply(rom, data)
+}
+
+// bootROMOption loads either a single boot ROM, or boot ROMs of each model from directory.
+func bootROMOption(path string) (emulator.Option, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		roms, err := emulator.LoadBootROMs(path)
+		if err != nil {
+			return nil, err
+		}
+		return emulator.WithBootROMs(roms), nil
+	}
+	rom, err := emulator.LoadBootROM(path)
+	if err != nil {
+		return nil, err
+	}
+	return emulator.WithBootROM(rom), nil
 }
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/v4t/gomb/pkg/cartridge"
+	"github.com/v4t/gomb/pkg/loader"
+)
+
+const sramUsage = `Usage:
+  gomb sram export [-format bin|hex] [-entry name] ROM OUTPUT
+  gomb sram import [-format bin|hex] [-entry name] ROM INPUT
+
+Export writes cartridge RAM from the save file of ROM to OUTPUT.
+Import replaces contents of the save file of ROM with INPUT.
+`
+
+// sramCommand handles the sram subcommand for exporting and importing battery backed RAM.
+func sramCommand(args []string) {
+	if len(args) == 0 || (args[0] != "export" && args[0] != "import") {
+		fmt.Fprint(os.Stderr, sramUsage)
+		os.Exit(2)
+	}
+	flags := flag.NewFlagSet("sram "+args[0], flag.ExitOnError)
+	format := flags.String("format", "bin", "file format, either bin for raw binary or hex for hex dump")
+	entry := flags.String("entry", "", "ROM file loaded from zip archive (default: first .gb or .gbc file)")
+	flags.Usage = func() { fmt.Fprint(os.Stderr, sramUsage) }
+	flags.Parse(args[1:])
+	if flags.NArg() != 2 || (*format != "bin" && *format != "hex") {
+		flags.Usage()
+		os.Exit(2)
+	}
+	romFile, file := flags.Arg(0), flags.Arg(1)
+
+	rom, err := loader.Load(romFile, *entry)
+	if err != nil {
+		log.Fatalf("Error when loading ROM: %v", err)
+	}
+	cart, err := cartridge.NewCartridge(rom)
+	if err != nil {
+		log.Fatalf("Error when loading cartridge: %v", err)
+	}
+	if !cart.Battery {
+		log.Fatalf("Cartridge has no battery backed RAM")
+	}
+	if err := cart.LoadSaveFile(cartridge.SavePath(loader.ROMPath(romFile))); err != nil {
+		log.Fatalf("Error when loading save file: %v", err)
+	}
+
+	if args[0] == "export" {
+		data := cart.RAMSnapshot()
+		if *format == "hex" {
+			data = []byte(hex.Dump(data))
+		}
+		if err := ioutil.WriteFile(file, data, 0644); err != nil {
+			log.Fatalf("Error when writing %s: %v", file, err)
+		}
+		return
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Fatalf("Error when reading %s: %v", file, err)
+	}
+	if *format == "hex" {
+		if data, err = parseHexDump(data); err != nil {
+			log.Fatalf("Error when reading %s: %v", file, err)
+		}
+	}
+	if err := cart.LoadRAM(data); err != nil {
+		log.Fatalf("Error when importing RAM: %v", err)
+	}
+	if err := cart.Flush(); err != nil {
+		log.Fatalf("Error when writing save file: %v", err)
+	}
+}
+
+// parseHexDump reads data written in the format of hex.Dump. The offset column and
+// the character column between | characters are ignored.
+func parseHexDump(dump []byte) ([]byte, error) {
+	var data []byte
+	scanner := bufio.NewScanner(bytes.NewReader(dump))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if i := strings.Index(text, "|"); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			b, err := hex.DecodeString(field)
+			if err != nil || len(b) != 1 {
+				return nil, fmt.Errorf("line %d: invalid byte %q", line, field)
+			}
+			data = append(data, b[0])
+		}
+	}
+	return data, scanner.Err()
+}
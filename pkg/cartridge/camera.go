@@ -0,0 +1,184 @@
+package cartridge
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+)
+
+// Size of the image captured by Pocket Camera sensor.
+const (
+	CameraWidth  = 128
+	CameraHeight = 112
+)
+
+// Camera register indices.
+const (
+	cameraControl   = 0x00
+	cameraEdge      = 0x01
+	cameraExposure  = 0x02
+	cameraVoltage   = 0x04
+	cameraDithering = 0x06
+	cameraRegisters = 0x36
+)
+
+// Edge enhancement ratios selected by bits 4-6 of register 4.
+var cameraEdgeRatios = [8]float64{0.50, 0.75, 1.00, 1.25, 2.00, 3.00, 4.00, 5.00}
+
+// ImageSource provides images for the Pocket Camera sensor.
+// Image may be of any size, it is scaled to the sensor resolution.
+type ImageSource interface {
+	Image() image.Image
+}
+
+// ImageSensor is implemented by memory bank controllers that contain an image sensor.
+type ImageSensor interface {
+	SetImageSource(source ImageSource)
+}
+
+// StaticImage is an image source which always returns the same image.
+type StaticImage struct {
+	Img image.Image
+}
+
+// Image returns the static image.
+func (source *StaticImage) Image() image.Image {
+	return source.Img
+}
+
+// LoadImageFile reads a PNG file to be used as a static image source.
+func LoadImageFile(path string) (*StaticImage, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticImage{Img: img}, nil
+}
+
+// sensorImage returns grayscale image from source scaled to sensor resolution.
+// Missing source produces a blank gray image.
+func sensorImage(source ImageSource) *image.Gray {
+	gray := image.NewGray(image.Rect(0, 0, CameraWidth, CameraHeight))
+	var img image.Image
+	if source != nil {
+		img = source.Image()
+	}
+	if img == nil {
+		draw.Draw(gray, gray.Bounds(), image.NewUniform(color.Gray{Y: 0x80}), image.ZP, draw.Src)
+		return gray
+	}
+	bounds := img.Bounds()
+	for y := 0; y < CameraHeight; y++ {
+		for x := 0; x < CameraWidth; x++ {
+			sx := bounds.Min.X + x*bounds.Dx()/CameraWidth
+			sy := bounds.Min.Y + y*bounds.Dy()/CameraHeight
+			gray.Set(x, y, img.At(sx, sy))
+		}
+	}
+	return gray
+}
+
+// capture processes sensor image with camera registers and returns 2bpp tile data
+// of 16x14 tiles in the layout the camera writes to RAM.
+func capture(source ImageSource, registers []byte) []byte {
+	gray := sensorImage(source)
+
+	// Exposure time scales brightness, 0x0300 leaves the image as it is
+	exposure := float64(uint16(registers[cameraExposure])<<8|uint16(registers[cameraExposure+1])) / 0x0300
+	pixels := make([]float64, CameraWidth*CameraHeight)
+	for i := range pixels {
+		pixels[i] = float64(gray.Pix[i]) * exposure
+	}
+
+	pixels = enhanceEdges(pixels, registers)
+
+	invert := registers[cameraVoltage]&0x08 != 0
+	tiles := make([]byte, CameraWidth*CameraHeight/4)
+	for y := 0; y < CameraHeight; y++ {
+		for x := 0; x < CameraWidth; x++ {
+			value := clampColor(pixels[y*CameraWidth+x])
+			if invert {
+				value = 0xff - value
+			}
+			shade := ditherColor(registers, x, y, value)
+
+			offset := ((y/8)*(CameraWidth/8)+x/8)*16 + (y%8)*2
+			bit := byte(0x80) >> uint(x%8)
+			if shade&0x01 != 0 {
+				tiles[offset] |= bit
+			}
+			if shade&0x02 != 0 {
+				tiles[offset+1] |= bit
+			}
+		}
+	}
+	return tiles
+}
+
+// enhanceEdges applies edge enhancement selected by VH bits of register 1.
+// Horizontal enhancement compares pixels to left and right neighbours and vertical to ones above and below.
+func enhanceEdges(pixels []float64, registers []byte) []float64 {
+	mode := (registers[cameraEdge] >> 5) & 0x03
+	if mode == 0 {
+		return pixels
+	}
+	ratio := cameraEdgeRatios[(registers[cameraVoltage]>>4)&0x07]
+	at := func(x, y int) float64 {
+		if x < 0 {
+			x = 0
+		} else if x >= CameraWidth {
+			x = CameraWidth - 1
+		}
+		if y < 0 {
+			y = 0
+		} else if y >= CameraHeight {
+			y = CameraHeight - 1
+		}
+		return pixels[y*CameraWidth+x]
+	}
+
+	result := make([]float64, len(pixels))
+	for y := 0; y < CameraHeight; y++ {
+		for x := 0; x < CameraWidth; x++ {
+			p := at(x, y)
+			edge := 0.0
+			if mode&0x01 != 0 {
+				edge += 2*p - at(x-1, y) - at(x+1, y)
+			}
+			if mode&0x02 != 0 {
+				edge += 2*p - at(x, y-1) - at(x, y+1)
+			}
+			result[y*CameraWidth+x] = p + ratio*edge
+		}
+	}
+	return result
+}
+
+// ditherColor converts pixel value to a 2-bit shade using thresholds from the 4x4 dithering matrix.
+func ditherColor(registers []byte, x, y int, value byte) byte {
+	base := cameraDithering + ((y%4)*4+x%4)*3
+	if value < registers[base] {
+		return 3
+	} else if value < registers[base+1] {
+		return 2
+	} else if value < registers[base+2] {
+		return 1
+	}
+	return 0
+}
+
+func clampColor(value float64) byte {
+	if value < 0 {
+		return 0
+	} else if value > 0xff {
+		return 0xff
+	}
+	return byte(value)
+}
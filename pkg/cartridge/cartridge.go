@@ -1,6 +1,7 @@
 package cartridge
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -10,34 +11,188 @@ type MBC interface {
 	ReadMemory(address uint16) byte
 }
 
+// Rumble is implemented by memory bank controllers that can drive a rumble motor.
+type Rumble interface {
+	SetRumbleHandler(handler func(on bool))
+}
+
+// ExternalRAM is implemented by memory bank controllers that have RAM in cartridge.
+type ExternalRAM interface {
+	RAM() []byte
+	RAMEnabled() bool
+}
+
+// ROMPatcher modifies values read from ROM, e.g. for applying cheat codes.
+type ROMPatcher interface {
+	PatchROM(address uint16, value byte) byte
+}
+
 // Cartridge manages gameboy cartridge related functionality.
 type Cartridge struct {
-	Title string
-	mbc   MBC
+	Header  Header
+	Battery bool
+	mbc     MBC
+	patcher ROMPatcher
+
+	// Checksums of ROM and the matching entry in ROM database, nil for unknown dumps
+	CRC32    uint32
+	SHA1     string
+	Dump     *Dump
+	Override Override
+
+	// Save file state for battery backed RAM
+	savePath     string
+	dirty        bool
+	flushPending bool
 }
 
 // NewCartridge initializes cartridge based on cartridge header.
-func NewCartridge(rom []byte) *Cartridge {
-	cart := &Cartridge{}
-	cart.Title = strings.Trim(string(rom[0x0134:0x0142]), "\x00")
+func NewCartridge(rom []byte) (*Cartridge, error) {
+	if len(rom) < headerEnd {
+		return nil, &TruncatedROMError{Size: len(rom)}
+	}
+	cart := &Cartridge{Header: NewHeader(rom)}
+	if isMMM01(rom) {
+		// MMM01 cartridges boot to a menu which header is located at the end of ROM
+		cart.Header = NewHeader(rom[len(rom)-0x8000:])
+	}
 
-	mbcType := rom[0x0147]
-	if mbcType >= 0x00 && mbcType <= 0x03 {
-		cart.mbc = NewMBC1(rom)
+	// ROMs larger than declared size are accepted, since some dumps contain extra data
+	if size := cart.Header.ROMSize(); size == 0 || len(rom) < size {
+		return nil, &SizeMismatchError{HeaderSize: size, ActualSize: len(rom)}
+	}
+	ramSize := cart.Header.RAMSize()
+
+	cart.CRC32, cart.SHA1 = Checksums(rom)
+	if dump, ok := Database.Lookup(cart.CRC32, cart.SHA1); ok {
+		cart.Dump = &dump
+	}
+	mbcType := cart.Header.CartridgeType
+	if override, ok := Database.Override(cart.SHA1); ok {
+		cart.Override = override
+		if override.HasCartridgeType {
+			mbcType = override.CartridgeType
+		}
+	}
+	cart.Battery = hasBattery(mbcType)
+	if mbcType == 0x00 {
+		cart.mbc = NewROM(rom, 0)
+	} else if mbcType >= 0x01 && mbcType <= 0x03 {
+		cart.mbc = NewMBC1(rom, ramSize)
 	} else if mbcType >= 0x05 && mbcType <= 0x06 {
 		cart.mbc = NewMBC2(rom)
+	} else if mbcType >= 0x08 && mbcType <= 0x09 {
+		cart.mbc = NewROM(rom, ramSize)
+	} else if mbcType >= 0x0b && mbcType <= 0x0d {
+		cart.mbc = NewMMM01(rom, ramSize)
+	} else if mbcType >= 0x0f && mbcType <= 0x10 {
+		cart.mbc = NewMBC3(rom, ramSize, NewRTC(SystemTime{}))
+	} else if mbcType >= 0x11 && mbcType <= 0x13 {
+		cart.mbc = NewMBC3(rom, ramSize, nil)
+	} else if mbcType >= 0x19 && mbcType <= 0x1b {
+		cart.mbc = NewMBC5(rom, ramSize, false)
+	} else if mbcType >= 0x1c && mbcType <= 0x1e {
+		cart.mbc = NewMBC5(rom, ramSize, true)
+	} else if mbcType == 0x22 {
+		cart.mbc = NewMBC7(rom)
+	} else if mbcType == 0xfc {
+		cart.mbc = NewPocketCamera(rom)
+	} else if mbcType == 0xfe {
+		cart.mbc = NewHuC3(rom, ramSize, SystemTime{})
+	} else if mbcType == 0xff {
+		cart.mbc = NewHuC1(rom, ramSize)
 	} else {
-		panic("MBC type not implemented for this cartridge.")
+		return nil, &UnsupportedMapperError{Type: mbcType}
 	}
-	return cart
+	return cart, nil
+}
+
+// String returns cartridge summary.
+func (cart *Cartridge) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v\n", cart.Header)
+	fmt.Fprintf(&b, "Battery:         %t\n", cart.Battery)
+	fmt.Fprintf(&b, "CRC32/SHA-1:     %08x/%s\n", cart.CRC32, cart.SHA1)
+	if cart.Dump == nil {
+		fmt.Fprintf(&b, "Known dump:      No")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Known dump:      %s\n", cart.Dump.Name)
+	fmt.Fprintf(&b, "Region:          %s\n", cart.Dump.Region)
+	fmt.Fprintf(&b, "Dump status:     %v", cart.Dump.Status)
+	return b.String()
 }
 
 // Read from ROM or RAM using memory banking controller.
 func (cart *Cartridge) Read(address uint16) byte {
-	return cart.mbc.ReadMemory(address)
+	value := cart.mbc.ReadMemory(address)
+	if address < 0x8000 && cart.patcher != nil {
+		return cart.patcher.PatchROM(address, value)
+	}
+	return value
 }
 
 // Write to ROM or RAM memory banking controller.
 func (cart *Cartridge) Write(address uint16, value byte) {
+	ram, ok := cart.mbc.(ExternalRAM)
+	if !ok || !cart.Battery {
+		cart.mbc.WriteMemory(address, value)
+		return
+	}
+	wasEnabled := ram.RAMEnabled()
 	cart.mbc.WriteMemory(address, value)
+	if address >= 0xa000 && address < 0xc000 && wasEnabled {
+		cart.dirty = true
+	}
+	// Games disable RAM after saving, so that is a good moment for flushing RAM to disk
+	if wasEnabled && !ram.RAMEnabled() && cart.dirty {
+		cart.flushPending = true
+	}
+}
+
+// SetROMPatcher sets patcher that may replace values read from ROM.
+// Patcher receives the value from currently mapped ROM bank.
+func (cart *Cartridge) SetROMPatcher(patcher ROMPatcher) {
+	cart.patcher = patcher
+}
+
+// SetRumbleHandler registers function that is called when rumble motor is turned on or off.
+// Handler is never called for cartridges without rumble motor.
+func (cart *Cartridge) SetRumbleHandler(handler func(on bool)) {
+	if rumble, ok := cart.mbc.(Rumble); ok {
+		rumble.SetRumbleHandler(handler)
+	}
+}
+
+// SetTiltSource sets source for accelerometer values.
+// Source is never used for cartridges without accelerometer.
+func (cart *Cartridge) SetTiltSource(source TiltSource) {
+	if accelerometer, ok := cart.mbc.(Accelerometer); ok {
+		accelerometer.SetTiltSource(source)
+	}
+}
+
+// SetTimeSource sets source for current time of real time clock. Cartridges use system
+// time by default. Source is never used for cartridges without real time clock.
+func (cart *Cartridge) SetTimeSource(source TimeSource) {
+	if clock, ok := cart.mbc.(RealTimeClock); ok {
+		clock.SetTimeSource(source)
+	}
+}
+
+// SetImageSource sets source for images captured by camera.
+// Source is never used for cartridges without image sensor.
+func (cart *Cartridge) SetImageSource(source ImageSource) {
+	if sensor, ok := cart.mbc.(ImageSensor); ok {
+		sensor.SetImageSource(source)
+	}
+}
+
+// hasBattery checks from cartridge type if cartridge RAM is battery backed.
+func hasBattery(mbcType byte) bool {
+	switch mbcType {
+	case 0x03, 0x06, 0x09, 0x0d, 0x0f, 0x10, 0x13, 0x1b, 0x1e, 0x22, 0xfc, 0xfe, 0xff:
+		return true
+	}
+	return false
 }
@@ -0,0 +1,105 @@
+package cartridge
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func createROM(mbcType byte, banks int) []byte {
+	rom := make([]byte, banks*0x4000)
+	copy(rom[0x134:], "TEST")
+	rom[0x147] = mbcType
+	rom[0x149] = 0x03
+	for size := 0x8000; size < len(rom); size <<= 1 {
+		rom[0x148]++
+	}
+	return rom
+}
+
+func newTestCartridge(t *testing.T, rom []byte) *Cartridge {
+	cart, err := NewCartridge(rom)
+	if err != nil {
+		t.Fatalf("Creating cartridge failed: %v", err)
+	}
+	return cart
+}
+
+func TestTruncatedROM(t *testing.T) {
+	_, err := NewCartridge(make([]byte, 0x100))
+	var truncated *TruncatedROMError
+	if !errors.As(err, &truncated) {
+		t.Errorf("Expected truncated ROM error, got %v", err)
+	}
+}
+
+func TestUnsupportedMapper(t *testing.T) {
+	_, err := NewCartridge(createROM(0x20, 2))
+	var unsupported *UnsupportedMapperError
+	if !errors.As(err, &unsupported) || unsupported.Type != 0x20 {
+		t.Errorf("Expected unsupported mapper error for type 0x20, got %v", err)
+	}
+}
+
+func TestROMSizeMismatch(t *testing.T) {
+	rom := createROM(0x01, 4)
+	_, err := NewCartridge(rom[:0x8000])
+	var mismatch *SizeMismatchError
+	if !errors.As(err, &mismatch) || mismatch.HeaderSize != 0x10000 || mismatch.ActualSize != 0x8000 {
+		t.Errorf("Expected size mismatch error, got %v", err)
+	}
+
+	rom[0x148] = 0x20
+	_, err = NewCartridge(rom)
+	if !errors.As(err, &mismatch) || mismatch.HeaderSize != 0 {
+		t.Errorf("Expected size mismatch error for invalid size code, got %v", err)
+	}
+}
+
+func TestOutOfRangeBankWraps(t *testing.T) {
+	rom := createROM(0x19, 4)
+	rom[0x4000*3] = 0x33
+	cart := newTestCartridge(t, rom)
+
+	// Bank 7 doesn't exist in 4 bank ROM and wraps to bank 3
+	cart.Write(0x2000, 0x07)
+	if value := cart.Read(0x4000); value != 0x33 {
+		t.Errorf("Bank 7 should wrap to bank 3 and read %x, got %x", 0x33, value)
+	}
+}
+
+func TestUnmappedReadReturnsOpenBus(t *testing.T) {
+	cart := newTestCartridge(t, createROM(0x01, 2))
+	if value := cart.Read(0x9000); value != 0xff {
+		t.Errorf("Unmapped address should read %x, got %x", 0xff, value)
+	}
+}
+
+func TestCartridgeTimeSource(t *testing.T) {
+	clock := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cart := newTestCartridge(t, createROM(0x10, 4))
+	cart.SetTimeSource(clock)
+	clock.advance(3*time.Hour + 4*time.Minute + 5*time.Second)
+
+	cart.Write(0x0000, 0x0a)
+	cart.Write(0x6000, 0x00)
+	cart.Write(0x6000, 0x01)
+	expected := map[byte]byte{rtcSeconds: 5, rtcMinutes: 4, rtcHours: 3}
+	for register, want := range expected {
+		cart.Write(0x4000, register)
+		if value := cart.Read(0xa000); value != want {
+			t.Errorf("RTC register %x should be %d, got %d", register, want, value)
+		}
+	}
+
+	clock = &fakeTime{now: time.Unix(0, 0)}
+	cart = newTestCartridge(t, createROM(0xfe, 4))
+	cart.SetTimeSource(clock)
+	clock.advance(2*24*time.Hour + 90*time.Minute)
+	if minutes, days := readHuC3Time(cart.mbc.(*HuC3)); minutes != 90 || days != 2 {
+		t.Errorf("HuC3 clock should be at 2 days and 90 minutes, got %d days and %d minutes", days, minutes)
+	}
+
+	// Setting source for cartridge without clock is ignored
+	newTestCartridge(t, createROM(0x13, 4)).SetTimeSource(clock)
+}
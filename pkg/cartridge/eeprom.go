@@ -0,0 +1,160 @@
+package cartridge
+
+// EEPROM states.
+const (
+	eepromIdle = iota
+	eepromCommand
+	eepromReading
+	eepromWriting
+	eepromWritingAll
+)
+
+// eeprom emulates 93LC56 serial EEPROM with 128 16-bit words.
+// Data is shifted in and out one bit at a time on rising edges of the clock signal.
+type eeprom struct {
+	data []byte
+
+	cs  bool
+	clk bool
+	di  bool
+	do  bool
+
+	state        int
+	shift        uint32
+	bits         int
+	address      byte
+	writeEnabled bool
+}
+
+func newEEPROM() *eeprom {
+	data := make([]byte, 0x100)
+	for i := range data {
+		data[i] = 0xff
+	}
+	return &eeprom{data: data, do: true}
+}
+
+// read returns state of the serial pins, data output being in bit 0.
+func (e *eeprom) read() byte {
+	var value byte
+	if e.cs {
+		value |= 0x80
+	}
+	if e.clk {
+		value |= 0x40
+	}
+	if e.di {
+		value |= 0x02
+	}
+	if e.do {
+		value |= 0x01
+	}
+	return value
+}
+
+// write sets chip select (bit 7), clock (bit 6) and data input (bit 1) pins.
+func (e *eeprom) write(value byte) {
+	cs := value&0x80 != 0
+	clk := value&0x40 != 0
+	e.di = value&0x02 != 0
+
+	if !cs {
+		// Deselecting the chip aborts current operation
+		e.state = eepromIdle
+		e.do = true
+	} else if clk && !e.clk {
+		e.clockIn()
+	}
+	e.cs = cs
+	e.clk = clk
+}
+
+// clockIn handles rising clock edge.
+func (e *eeprom) clockIn() {
+	bit := uint32(0)
+	if e.di {
+		bit = 1
+	}
+	switch e.state {
+	case eepromIdle:
+		// Commands begin with a start bit
+		if bit == 1 {
+			e.state = eepromCommand
+			e.shift, e.bits = 0, 0
+		}
+	case eepromCommand:
+		e.shift = e.shift<<1 | bit
+		e.bits++
+		if e.bits == 10 {
+			e.decode(byte(e.shift>>8), byte(e.shift))
+		}
+	case eepromReading:
+		e.do = e.shift&0x8000 != 0
+		e.shift <<= 1
+		e.bits++
+		if e.bits == 16 {
+			// Sequential read continues from the next word
+			e.address = (e.address + 1) & 0x7f
+			e.shift, e.bits = uint32(e.word(e.address)), 0
+		}
+	case eepromWriting, eepromWritingAll:
+		e.shift = e.shift<<1 | bit
+		e.bits++
+		if e.bits == 16 {
+			if e.writeEnabled {
+				if e.state == eepromWriting {
+					e.setWord(e.address, uint16(e.shift))
+				} else {
+					for i := byte(0); i < 0x80; i++ {
+						e.setWord(i, uint16(e.shift))
+					}
+				}
+			}
+			e.state = eepromIdle
+			e.do = true
+		}
+	}
+}
+
+// decode command from 2-bit opcode and 8-bit address.
+func (e *eeprom) decode(opcode byte, address byte) {
+	e.address = address & 0x7f
+	e.shift, e.bits = 0, 0
+	e.state = eepromIdle
+	switch opcode {
+	case 0x2: // READ
+		e.shift = uint32(e.word(e.address))
+		e.state = eepromReading
+		e.do = false // Dummy zero bit precedes data
+	case 0x1: // WRITE
+		e.state = eepromWriting
+	case 0x3: // ERASE
+		if e.writeEnabled {
+			e.setWord(e.address, 0xffff)
+		}
+	case 0x0:
+		switch address >> 6 {
+		case 0x0: // EWDS
+			e.writeEnabled = false
+		case 0x1: // WRAL
+			e.state = eepromWritingAll
+		case 0x2: // ERAL
+			if e.writeEnabled {
+				for i := range e.data {
+					e.data[i] = 0xff
+				}
+			}
+		case 0x3: // EWEN
+			e.writeEnabled = true
+		}
+	}
+}
+
+func (e *eeprom) word(address byte) uint16 {
+	return uint16(e.data[int(address)*2]) | uint16(e.data[int(address)*2+1])<<8
+}
+
+func (e *eeprom) setWord(address byte, value uint16) {
+	e.data[int(address)*2] = byte(value)
+	e.data[int(address)*2+1] = byte(value >> 8)
+}
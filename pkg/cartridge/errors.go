@@ -0,0 +1,47 @@
+package cartridge
+
+import "fmt"
+
+// TruncatedROMError is returned when ROM data is too small to contain cartridge header.
+type TruncatedROMError struct {
+	Size int
+}
+
+func (err *TruncatedROMError) Error() string {
+	return fmt.Sprintf("ROM is truncated: %d bytes is too small to contain cartridge header", err.Size)
+}
+
+// UnsupportedMapperError is returned when cartridge uses memory bank controller that is not implemented.
+type UnsupportedMapperError struct {
+	Type byte
+}
+
+func (err *UnsupportedMapperError) Error() string {
+	return fmt.Sprintf("unsupported cartridge type 0x%02x", err.Type)
+}
+
+// SizeMismatchError is returned when ROM size doesn't match size declared in cartridge header.
+type SizeMismatchError struct {
+	HeaderSize int
+	ActualSize int
+}
+
+func (err *SizeMismatchError) Error() string {
+	if err.HeaderSize == 0 {
+		return fmt.Sprintf("invalid ROM size in cartridge header, actual size is %d bytes", err.ActualSize)
+	}
+	return fmt.Sprintf("ROM size mismatch: header declares %d bytes, got %d bytes", err.HeaderSize, err.ActualSize)
+}
+
+// RAMSizeError is returned when loaded RAM data doesn't match the size of cartridge RAM.
+type RAMSizeError struct {
+	Expected int
+	Actual   int
+}
+
+func (err *RAMSizeError) Error() string {
+	if err.Expected == 0 {
+		return "cartridge has no external RAM"
+	}
+	return fmt.Sprintf("RAM size mismatch: cartridge has %d bytes of RAM, got %d bytes", err.Expected, err.Actual)
+}
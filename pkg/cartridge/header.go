@@ -0,0 +1,235 @@
+package cartridge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Header contains information parsed from cartridge header at 0x0100-0x014f.
+type Header struct {
+	Title            string
+	ManufacturerCode string
+	CGBFlag          byte
+	SGBFlag          byte
+	NewLicenseeCode  string
+	OldLicenseeCode  byte
+	CartridgeType    byte
+	ROMSizeCode      byte
+	RAMSizeCode      byte
+	Destination      byte
+	Version          byte
+
+	HeaderChecksum      byte
+	GlobalChecksum      uint16
+	HeaderChecksumValid bool
+	GlobalChecksumValid bool
+}
+
+// headerEnd is the first address after cartridge header.
+const headerEnd = 0x150
+
+// nintendoLogo is the logo bitmap stored at 0x0104-0x0133 of every licensed cartridge.
+var nintendoLogo = []byte{
+	0xce, 0xed, 0x66, 0x66, 0xcc, 0x0d, 0x00, 0x0b, 0x03, 0x73, 0x00, 0x83, 0x00, 0x0c, 0x00, 0x0d,
+	0x00, 0x08, 0x11, 0x1f, 0x88, 0x89, 0x00, 0x0e, 0xdc, 0xcc, 0x6e, 0xe6, 0xdd, 0xdd, 0xd9, 0x99,
+	0xbb, 0xbb, 0x67, 0x63, 0x6e, 0x0e, 0xec, 0xcc, 0xdd, 0xdc, 0x99, 0x9f, 0xbb, 0xb9, 0x33, 0x3e,
+}
+
+// Cartridge type names as specified in cartridge header.
+var cartridgeTypeNames = map[byte]string{
+	0x00: "ROM ONLY",
+	0x01: "MBC1",
+	0x02: "MBC1+RAM",
+	0x03: "MBC1+RAM+BATTERY",
+	0x05: "MBC2",
+	0x06: "MBC2+BATTERY",
+	0x08: "ROM+RAM",
+	0x09: "ROM+RAM+BATTERY",
+	0x0b: "MMM01",
+	0x0c: "MMM01+RAM",
+	0x0d: "MMM01+RAM+BATTERY",
+	0x0f: "MBC3+TIMER+BATTERY",
+	0x10: "MBC3+TIMER+RAM+BATTERY",
+	0x11: "MBC3",
+	0x12: "MBC3+RAM",
+	0x13: "MBC3+RAM+BATTERY",
+	0x19: "MBC5",
+	0x1a: "MBC5+RAM",
+	0x1b: "MBC5+RAM+BATTERY",
+	0x1c: "MBC5+RUMBLE",
+	0x1d: "MBC5+RUMBLE+RAM",
+	0x1e: "MBC5+RUMBLE+RAM+BATTERY",
+	0x20: "MBC6",
+	0x22: "MBC7+SENSOR+RUMBLE+RAM+BATTERY",
+	0xfc: "POCKET CAMERA",
+	0xfd: "BANDAI TAMA5",
+	0xfe: "HuC3",
+	0xff: "HuC1+RAM+BATTERY",
+}
+
+// NewHeader parses cartridge header from ROM data.
+func NewHeader(rom []byte) Header {
+	header := Header{
+		CGBFlag:         rom[0x143],
+		NewLicenseeCode: strings.Trim(string(rom[0x144:0x146]), "\x00"),
+		SGBFlag:         rom[0x146],
+		CartridgeType:   rom[0x147],
+		ROMSizeCode:     rom[0x148],
+		RAMSizeCode:     rom[0x149],
+		Destination:     rom[0x14a],
+		OldLicenseeCode: rom[0x14b],
+		Version:         rom[0x14c],
+		HeaderChecksum:  rom[0x14d],
+		GlobalChecksum:  uint16(rom[0x14e])<<8 | uint16(rom[0x14f]),
+	}
+
+	// Newer cartridges use the end of title area for manufacturer code and CGB flag
+	if header.SupportsCGB() {
+		header.Title = trimTitle(rom[0x134:0x13f])
+		if isManufacturerCode(rom[0x13f:0x143]) {
+			header.ManufacturerCode = string(rom[0x13f:0x143])
+		} else {
+			header.Title = trimTitle(rom[0x134:0x143])
+		}
+	} else {
+		header.Title = trimTitle(rom[0x134:0x144])
+	}
+
+	header.HeaderChecksumValid = headerChecksum(rom) == header.HeaderChecksum
+	header.GlobalChecksumValid = globalChecksum(rom) == header.GlobalChecksum
+	return header
+}
+
+// SupportsCGB checks if cartridge supports Game Boy Color functions.
+func (header Header) SupportsCGB() bool {
+	return header.CGBFlag&0x80 != 0
+}
+
+// RequiresCGB checks if cartridge works only on Game Boy Color.
+func (header Header) RequiresCGB() bool {
+	return header.CGBFlag == 0xc0
+}
+
+// SupportsSGB checks if cartridge supports Super Game Boy functions.
+func (header Header) SupportsSGB() bool {
+	return header.SGBFlag == 0x03 && header.OldLicenseeCode == 0x33
+}
+
+// Licensee returns publisher code of the cartridge.
+func (header Header) Licensee() string {
+	if header.OldLicenseeCode == 0x33 {
+		return header.NewLicenseeCode
+	}
+	return fmt.Sprintf("%02X", header.OldLicenseeCode)
+}
+
+// TypeName returns human readable name of cartridge type.
+func (header Header) TypeName() string {
+	if name, ok := cartridgeTypeNames[header.CartridgeType]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// ROMSize returns ROM size in bytes.
+func (header Header) ROMSize() int {
+	switch header.ROMSizeCode {
+	case 0x52:
+		return 72 * 0x4000
+	case 0x53:
+		return 80 * 0x4000
+	case 0x54:
+		return 96 * 0x4000
+	}
+	if header.ROMSizeCode <= 0x08 {
+		return 0x8000 << header.ROMSizeCode
+	}
+	return 0
+}
+
+// RAMSize returns external RAM size in bytes.
+func (header Header) RAMSize() int {
+	switch header.RAMSizeCode {
+	case 0x01:
+		return 0x800
+	case 0x02:
+		return 0x2000
+	case 0x03:
+		return 0x8000
+	case 0x04:
+		return 0x20000
+	case 0x05:
+		return 0x10000
+	}
+	return 0
+}
+
+// Japanese checks if cartridge is supposed to be sold in Japan.
+func (header Header) Japanese() bool {
+	return header.Destination == 0x00
+}
+
+// String returns summary of cartridge header.
+func (header Header) String() string {
+	var b strings.Builder
+	checksum := func(valid bool) string {
+		if valid {
+			return "OK"
+		}
+		return "INVALID"
+	}
+	destination := "Non-Japanese"
+	if header.Japanese() {
+		destination = "Japanese"
+	}
+	fmt.Fprintf(&b, "Title:           %s\n", header.Title)
+	if header.ManufacturerCode != "" {
+		fmt.Fprintf(&b, "Manufacturer:    %s\n", header.ManufacturerCode)
+	}
+	fmt.Fprintf(&b, "Type:            %s (0x%02x)\n", header.TypeName(), header.CartridgeType)
+	fmt.Fprintf(&b, "ROM size:        %d KB\n", header.ROMSize()/1024)
+	fmt.Fprintf(&b, "RAM size:        %d KB\n", header.RAMSize()/1024)
+	fmt.Fprintf(&b, "CGB/SGB support: %t/%t\n", header.SupportsCGB(), header.SupportsSGB())
+	fmt.Fprintf(&b, "Licensee:        %s\n", header.Licensee())
+	fmt.Fprintf(&b, "Destination:     %s\n", destination)
+	fmt.Fprintf(&b, "Version:         %d\n", header.Version)
+	fmt.Fprintf(&b, "Header checksum: 0x%02x %s\n", header.HeaderChecksum, checksum(header.HeaderChecksumValid))
+	fmt.Fprintf(&b, "Global checksum: 0x%04x %s", header.GlobalChecksum, checksum(header.GlobalChecksumValid))
+	return b.String()
+}
+
+// headerChecksum calculates checksum of header bytes 0x0134-0x014c.
+func headerChecksum(rom []byte) byte {
+	var sum byte
+	for _, value := range rom[0x134:0x14d] {
+		sum = sum - value - 1
+	}
+	return sum
+}
+
+// globalChecksum calculates sum of all ROM bytes except the global checksum itself.
+func globalChecksum(rom []byte) uint16 {
+	var sum uint16
+	for i, value := range rom {
+		if i != 0x14e && i != 0x14f {
+			sum += uint16(value)
+		}
+	}
+	return sum
+}
+
+func trimTitle(data []byte) string {
+	if i := strings.IndexByte(string(data), 0); i >= 0 {
+		data = data[:i]
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func isManufacturerCode(data []byte) bool {
+	for _, c := range data {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package cartridge
+
+import "testing"
+
+func TestHeaderParsing(t *testing.T) {
+	rom := createROM(0x1b, 4)
+	copy(rom[0x134:], "POKEMON_SLVAAXE")
+	rom[0x143] = 0x80
+	copy(rom[0x144:], "01")
+	rom[0x146] = 0x03
+	rom[0x148] = 0x06
+	rom[0x149] = 0x04
+	rom[0x14a] = 0x01
+	rom[0x14b] = 0x33
+	rom[0x14c] = 0x02
+	header := NewHeader(rom)
+
+	if header.Title != "POKEMON_SLV" {
+		t.Errorf("Title should be POKEMON_SLV, got %s", header.Title)
+	}
+	if header.ManufacturerCode != "AAXE" {
+		t.Errorf("Manufacturer code should be AAXE, got %s", header.ManufacturerCode)
+	}
+	if !header.SupportsCGB() || header.RequiresCGB() || !header.SupportsSGB() {
+		t.Errorf("Cartridge should support CGB and SGB without requiring CGB")
+	}
+	if header.Licensee() != "01" {
+		t.Errorf("Licensee should be 01, got %s", header.Licensee())
+	}
+	if header.ROMSize() != 0x200000 {
+		t.Errorf("ROM size should be %x, got %x", 0x200000, header.ROMSize())
+	}
+	if header.RAMSize() != 0x20000 {
+		t.Errorf("RAM size should be %x, got %x", 0x20000, header.RAMSize())
+	}
+	if header.Japanese() || header.Version != 2 {
+		t.Errorf("Destination or version was parsed incorrectly")
+	}
+}
+
+func TestHeaderTitleWithoutCGBFlag(t *testing.T) {
+	rom := createROM(0x00, 2)
+	copy(rom[0x134:], "SUPER MARIOLAND\x00")
+	header := NewHeader(rom)
+	if header.Title != "SUPER MARIOLAND" {
+		t.Errorf("Title should be SUPER MARIOLAND, got %s", header.Title)
+	}
+}
+
+func TestHeaderChecksums(t *testing.T) {
+	rom := createROM(0x00, 2)
+	rom[0x14d] = headerChecksum(rom)
+	sum := globalChecksum(rom)
+	rom[0x14e] = byte(sum >> 8)
+	rom[0x14f] = byte(sum)
+
+	header := NewHeader(rom)
+	if !header.HeaderChecksumValid || !header.GlobalChecksumValid {
+		t.Errorf("Checksums should be valid")
+	}
+
+	rom[0x134] = 'X'
+	header = NewHeader(rom)
+	if header.HeaderChecksumValid || header.GlobalChecksumValid {
+		t.Errorf("Checksums should be invalid after modifying title")
+	}
+}
+
+func TestCartridgeRAMSizeFromHeader(t *testing.T) {
+	rom := createROM(0x03, 2)
+	rom[0x149] = 0x02
+	cart := newTestCartridge(t, rom)
+	if size := len(cart.mbc.(ExternalRAM).RAM()); size != 0x2000 {
+		t.Errorf("RAM size should be %x, got %x", 0x2000, size)
+	}
+}
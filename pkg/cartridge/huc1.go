@@ -0,0 +1,92 @@
+package cartridge
+
+import "github.com/v4t/gomb/pkg/utils"
+
+// HuC1 represents Hudson Soft HuC1 memory bank controller.
+// In addition to RAM, HuC1 contains an infrared transceiver mapped to RAM area.
+type HuC1 struct {
+	rom           []byte
+	romBankNumber byte
+
+	ram           []byte
+	ramBankNumber byte
+
+	// IR mode replaces RAM with infrared register
+	irMode bool
+	irLED  bool
+}
+
+// NewHuC1 is a constructor for HuC1 type memory banking controller.
+func NewHuC1(rom []byte, ramSize int) *HuC1 {
+	return &HuC1{
+		rom:           rom,
+		ram:           make([]byte, ramSize),
+		romBankNumber: 1,
+	}
+}
+
+// WriteMemory handles writes to HuC1.
+func (mbc *HuC1) WriteMemory(address uint16, value byte) {
+	if address < 0x2000 {
+		// Value 0x0e selects IR mode, other values map RAM to RAM area
+		mbc.irMode = value&0x0f == 0x0e
+	} else if address < 0x4000 {
+		// Select ROM bank. Bank 0 is mapped to bank 1.
+		mbc.romBankNumber = value & 0x3f
+		if mbc.romBankNumber == 0 {
+			mbc.romBankNumber = 1
+		}
+	} else if address < 0x6000 {
+		// Select RAM bank
+		mbc.ramBankNumber = value & 0x03
+	} else if address >= 0xa000 && address < 0xc000 {
+		if mbc.irMode {
+			// Bit 0 controls IR LED
+			mbc.irLED = utils.TestBit(value, 0)
+		} else if len(mbc.ram) > 0 {
+			mbc.ram[mbc.mapAddressToRam(address)] = value
+		}
+	}
+}
+
+// ReadMemory handles reads from memory for HuC1.
+func (mbc *HuC1) ReadMemory(address uint16) byte {
+	if address < 0x4000 {
+		// ROM bank 0
+		return mbc.rom[address]
+	} else if address < 0x8000 {
+		// Switchable ROM bank
+		return mbc.rom[mbc.mapAddressToRom(address)]
+	} else if address >= 0xa000 && address < 0xc000 {
+		if mbc.irMode {
+			// IR receiver is not emulated, so no light is ever detected
+			return 0xc0
+		}
+		if len(mbc.ram) == 0 {
+			return 0xff
+		}
+		return mbc.ram[mbc.mapAddressToRam(address)]
+	}
+	// Unmapped addresses read as open bus
+	return 0xff
+}
+
+// RAM returns contents of cartridge RAM.
+func (mbc *HuC1) RAM() []byte {
+	return mbc.ram
+}
+
+// RAMEnabled checks if cartridge RAM is currently accessible.
+func (mbc *HuC1) RAMEnabled() bool {
+	return !mbc.irMode
+}
+
+func (mbc *HuC1) mapAddressToRom(address uint16) int {
+	bank := int(mbc.romBankNumber)
+	return (int(address-0x4000) + (bank * 0x4000)) % len(mbc.rom)
+}
+
+func (mbc *HuC1) mapAddressToRam(address uint16) int {
+	bank := int(mbc.ramBankNumber)
+	return (int(address-0xa000) + (bank * 0x2000)) % len(mbc.ram)
+}
@@ -0,0 +1,36 @@
+package cartridge
+
+import "testing"
+
+func TestHuC1Banking(t *testing.T) {
+	mbc := NewHuC1(createBankedROM(64), 0x8000)
+	mbc.WriteMemory(0x2000, 0x3c)
+	if value := mbc.ReadMemory(0x4000); value != 0x3c {
+		t.Errorf("ROM bank should be %x, got %x", 0x3c, value)
+	}
+
+	mbc.WriteMemory(0x4000, 0x03)
+	mbc.WriteMemory(0xa000, 0x42)
+	if value := mbc.ram[3*0x2000]; value != 0x42 {
+		t.Errorf("Write should go to RAM bank 3")
+	}
+}
+
+func TestHuC1IRMode(t *testing.T) {
+	mbc := NewHuC1(createBankedROM(4), 0x2000)
+	mbc.WriteMemory(0xa000, 0x55)
+
+	mbc.WriteMemory(0x0000, 0x0e)
+	mbc.WriteMemory(0xa000, 0x01)
+	if !mbc.irLED {
+		t.Error("IR LED should be turned on")
+	}
+	if value := mbc.ReadMemory(0xa000); value != 0xc0 {
+		t.Errorf("IR register should read %x, got %x", 0xc0, value)
+	}
+
+	mbc.WriteMemory(0x0000, 0x0a)
+	if value := mbc.ReadMemory(0xa000); value != 0x55 {
+		t.Errorf("RAM should not be modified in IR mode, expected %x, got %x", 0x55, value)
+	}
+}
@@ -0,0 +1,237 @@
+package cartridge
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// Modes of HuC3 selected by writes to 0x0000-0x1fff.
+const (
+	huc3ModeRAMReadOnly byte = 0x00
+	huc3ModeRAM         byte = 0x0a
+	huc3ModeCommand     byte = 0x0b
+	huc3ModeResponse    byte = 0x0c
+	huc3ModeSemaphore   byte = 0x0d
+	huc3ModeIR          byte = 0x0e
+)
+
+// HuC3 represents Hudson Soft HuC3 memory bank controller.
+// HuC3 contains a real time clock which is accessed with a command interface mapped to RAM area.
+type HuC3 struct {
+	rom           []byte
+	romBankNumber byte
+
+	ram           []byte
+	ramBankNumber byte
+
+	mode  byte
+	clock *huc3Clock
+}
+
+// NewHuC3 is a constructor for HuC3 type memory banking controller.
+func NewHuC3(rom []byte, ramSize int, source TimeSource) *HuC3 {
+	return &HuC3{
+		rom:           rom,
+		ram:           make([]byte, ramSize),
+		romBankNumber: 1,
+		clock:         newHuC3Clock(source),
+	}
+}
+
+// SetTimeSource sets source for current time of the clock.
+func (mbc *HuC3) SetTimeSource(source TimeSource) {
+	mbc.clock.setTimeSource(source)
+}
+
+// WriteMemory handles writes to HuC3.
+func (mbc *HuC3) WriteMemory(address uint16, value byte) {
+	if address < 0x2000 {
+		// Select what is mapped to RAM area
+		mbc.mode = value & 0x0f
+	} else if address < 0x4000 {
+		// Select ROM bank. Bank 0 is mapped to bank 1.
+		mbc.romBankNumber = value & 0x7f
+		if mbc.romBankNumber == 0 {
+			mbc.romBankNumber = 1
+		}
+	} else if address < 0x6000 {
+		// Select RAM bank
+		mbc.ramBankNumber = value & 0x03
+	} else if address >= 0xa000 && address < 0xc000 {
+		switch mbc.mode {
+		case huc3ModeRAM:
+			if len(mbc.ram) > 0 {
+				mbc.ram[mbc.mapAddressToRam(address)] = value
+			}
+		case huc3ModeCommand:
+			mbc.clock.execute(value)
+		}
+	}
+}
+
+// ReadMemory handles reads from memory for HuC3.
+func (mbc *HuC3) ReadMemory(address uint16) byte {
+	if address < 0x4000 {
+		// ROM bank 0
+		return mbc.rom[address]
+	} else if address < 0x8000 {
+		// Switchable ROM bank
+		return mbc.rom[mbc.mapAddressToRom(address)]
+	} else if address >= 0xa000 && address < 0xc000 {
+		switch mbc.mode {
+		case huc3ModeRAM, huc3ModeRAMReadOnly:
+			if len(mbc.ram) > 0 {
+				return mbc.ram[mbc.mapAddressToRam(address)]
+			}
+		case huc3ModeResponse:
+			return 0x80 | mbc.clock.command<<4 | mbc.clock.response
+		case huc3ModeSemaphore:
+			// Commands are executed immediately, so clock is always ready
+			return 0x01
+		case huc3ModeIR:
+			// IR receiver is not emulated, so no light is ever detected
+			return 0xc0
+		}
+	}
+	// Unmapped addresses read as open bus
+	return 0xff
+}
+
+// RAM returns contents of cartridge RAM.
+func (mbc *HuC3) RAM() []byte {
+	return mbc.ram
+}
+
+// RAMEnabled checks if cartridge RAM is currently writable.
+func (mbc *HuC3) RAMEnabled() bool {
+	return mbc.mode == huc3ModeRAM
+}
+
+func (mbc *HuC3) saveClock() []byte {
+	return mbc.clock.encode()
+}
+
+func (mbc *HuC3) loadClock(data []byte) {
+	mbc.clock.decode(data)
+}
+
+func (mbc *HuC3) mapAddressToRom(address uint16) int {
+	bank := int(mbc.romBankNumber)
+	return (int(address-0x4000) + (bank * 0x4000)) % len(mbc.rom)
+}
+
+func (mbc *HuC3) mapAddressToRam(address uint16) int {
+	bank := int(mbc.ramBankNumber)
+	return (int(address-0xa000) + (bank * 0x2000)) % len(mbc.ram)
+}
+
+// HuC3 clock commands. Upper nibble of written value contains the command and lower nibble the argument.
+const (
+	huc3CmdRead       byte = 0x1
+	huc3CmdWrite      byte = 0x3
+	huc3CmdAddressLow byte = 0x4
+	huc3CmdAddressHi  byte = 0x5
+	huc3CmdExtended   byte = 0x6
+)
+
+// huc3Clock counts minutes and days, and exposes them through a nibble addressed memory.
+type huc3Clock struct {
+	source     TimeSource
+	lastUpdate time.Time
+
+	minutes uint16 // Minutes since start of the day
+	days    uint16 // 12-bit day counter
+
+	memory   [0x100]byte
+	address  byte
+	command  byte
+	response byte
+}
+
+func newHuC3Clock(source TimeSource) *huc3Clock {
+	return &huc3Clock{
+		source:     source,
+		lastUpdate: source.Now(),
+	}
+}
+
+// setTimeSource replaces time source after counting time elapsed with previous source.
+func (clock *huc3Clock) setTimeSource(source TimeSource) {
+	clock.update()
+	clock.source = source
+	clock.lastUpdate = source.Now()
+}
+
+// execute clock command.
+func (clock *huc3Clock) execute(value byte) {
+	clock.command = value >> 4
+	arg := value & 0x0f
+	switch clock.command {
+	case huc3CmdRead:
+		clock.response = clock.memory[clock.address]
+		clock.address++
+	case huc3CmdWrite:
+		clock.memory[clock.address] = arg
+		clock.address++
+	case huc3CmdAddressLow:
+		clock.address = clock.address&0xf0 | arg
+	case huc3CmdAddressHi:
+		clock.address = clock.address&0x0f | arg<<4
+	case huc3CmdExtended:
+		switch arg {
+		case 0x0:
+			// Copy current time to memory
+			clock.update()
+			for i := 0; i < 3; i++ {
+				clock.memory[i] = byte(clock.minutes>>(i*4)) & 0x0f
+				clock.memory[i+3] = byte(clock.days>>(i*4)) & 0x0f
+			}
+		case 0x1:
+			// Set current time from memory
+			clock.minutes, clock.days = 0, 0
+			for i := 0; i < 3; i++ {
+				clock.minutes |= uint16(clock.memory[i]) << (i * 4)
+				clock.days |= uint16(clock.memory[i+3]) << (i * 4)
+			}
+			clock.lastUpdate = clock.source.Now()
+		case 0x2:
+			// Status check always succeeds
+			clock.response = 0x01
+		}
+	}
+}
+
+// update advances clock by the whole minutes elapsed since previous update.
+func (clock *huc3Clock) update() {
+	now := clock.source.Now()
+	elapsed := int64(now.Sub(clock.lastUpdate) / time.Minute)
+	if elapsed <= 0 {
+		return
+	}
+	clock.lastUpdate = clock.lastUpdate.Add(time.Duration(elapsed) * time.Minute)
+
+	total := int64(clock.minutes) + elapsed
+	clock.minutes = uint16(total % 1440)
+	clock.days = uint16((int64(clock.days) + total/1440) & 0xfff)
+}
+
+// encode clock state as minutes and days followed by a 64-bit unix timestamp.
+func (clock *huc3Clock) encode() []byte {
+	clock.update()
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint16(data[0:], clock.minutes)
+	binary.LittleEndian.PutUint16(data[2:], clock.days)
+	binary.LittleEndian.PutUint64(data[4:], uint64(clock.lastUpdate.Unix()))
+	return data
+}
+
+// decode clock state and add time passed since the state was saved.
+func (clock *huc3Clock) decode(data []byte) {
+	if len(data) < 12 {
+		return
+	}
+	clock.minutes = binary.LittleEndian.Uint16(data[0:])
+	clock.days = binary.LittleEndian.Uint16(data[2:])
+	clock.lastUpdate = time.Unix(int64(binary.LittleEndian.Uint64(data[4:])), 0)
+	clock.update()
+}
@@ -0,0 +1,71 @@
+package cartridge
+
+import (
+	"testing"
+	"time"
+)
+
+func huc3Command(mbc *HuC3, command, arg byte) byte {
+	mbc.WriteMemory(0x0000, huc3ModeCommand)
+	mbc.WriteMemory(0xa000, command<<4|arg)
+	mbc.WriteMemory(0x0000, huc3ModeResponse)
+	return mbc.ReadMemory(0xa000)
+}
+
+// readHuC3Time reads minutes and days through clock command interface.
+func readHuC3Time(mbc *HuC3) (minutes, days int) {
+	huc3Command(mbc, huc3CmdExtended, 0x0)
+	huc3Command(mbc, huc3CmdAddressLow, 0)
+	huc3Command(mbc, huc3CmdAddressHi, 0)
+	for i := 0; i < 6; i++ {
+		value := int(huc3Command(mbc, huc3CmdRead, 0) & 0x0f)
+		if i < 3 {
+			minutes |= value << (i * 4)
+		} else {
+			days |= value << ((i - 3) * 4)
+		}
+	}
+	return minutes, days
+}
+
+func TestHuC3RAMModes(t *testing.T) {
+	mbc := NewHuC3(createBankedROM(4), 0x8000, &fakeTime{})
+	mbc.WriteMemory(0x0000, huc3ModeRAM)
+	mbc.WriteMemory(0xa000, 0x42)
+
+	mbc.WriteMemory(0x0000, huc3ModeRAMReadOnly)
+	mbc.WriteMemory(0xa000, 0x24)
+	if value := mbc.ReadMemory(0xa000); value != 0x42 {
+		t.Errorf("RAM should be read only in mode 0, expected %x, got %x", 0x42, value)
+	}
+}
+
+func TestHuC3Clock(t *testing.T) {
+	clock := &fakeTime{now: time.Unix(0, 0)}
+	mbc := NewHuC3(createBankedROM(4), 0x8000, clock)
+	clock.advance(3*24*time.Hour + 90*time.Minute)
+
+	minutes, days := readHuC3Time(mbc)
+	if minutes != 90 || days != 3 {
+		t.Errorf("Clock should be at 3 days and 90 minutes, got %d days and %d minutes", days, minutes)
+	}
+}
+
+func TestHuC3SetClock(t *testing.T) {
+	clock := &fakeTime{now: time.Unix(0, 0)}
+	mbc := NewHuC3(createBankedROM(4), 0x8000, clock)
+
+	// Write 0x123 minutes and 0x045 days to memory and set clock from it
+	huc3Command(mbc, huc3CmdAddressLow, 0)
+	huc3Command(mbc, huc3CmdAddressHi, 0)
+	for _, value := range []byte{0x3, 0x2, 0x1, 0x5, 0x4, 0x0} {
+		huc3Command(mbc, huc3CmdWrite, value)
+	}
+	huc3Command(mbc, huc3CmdExtended, 0x1)
+	clock.advance(time.Minute)
+
+	minutes, days := readHuC3Time(mbc)
+	if minutes != 0x124 || days != 0x45 {
+		t.Errorf("Clock should be at 0x45 days and 0x124 minutes, got %x days and %x minutes", days, minutes)
+	}
+}
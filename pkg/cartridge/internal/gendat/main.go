@@ -0,0 +1,98 @@
+// Command gendat generates the DAT embedded in cartridge package from No-Intro "Nintendo - Game Boy"
+// and "Nintendo - Game Boy Color" DAT files. Only attributes used by the ROM database are kept.
+//
+// Usage:
+//
+//	go run ./internal/gendat -o romdb/gb.dat nointro-gb.dat [nointro-gbc.dat...]
+package main
+
+import (
+	"encoding/xml"
+	"flag"
+	"io"
+	"log"
+	"os"
+)
+
+type datFile struct {
+	XMLName xml.Name `xml:"datafile"`
+	Header  struct {
+		Name        string `xml:"name"`
+		Description string `xml:"description"`
+		Version     string `xml:"version"`
+	} `xml:"header"`
+	Games []game `xml:"game"`
+}
+
+type game struct {
+	Name string `xml:"name,attr"`
+	ROMs []rom  `xml:"rom"`
+}
+
+type rom struct {
+	Name   string `xml:"name,attr"`
+	Size   int    `xml:"size,attr"`
+	CRC    string `xml:"crc,attr"`
+	SHA1   string `xml:"sha1,attr,omitempty"`
+	Status string `xml:"status,attr,omitempty"`
+}
+
+func main() {
+	output := flag.String("o", "", "output file")
+	flag.Parse()
+	if *output == "" || flag.NArg() == 0 {
+		log.Fatalf("Usage: gendat -o output.dat input.dat...")
+	}
+
+	var merged datFile
+	merged.Header.Name = "gomb"
+	merged.Header.Description = "Generated from No-Intro DAT files with gendat, do not edit"
+	for _, path := range flag.Args() {
+		dat, err := readDAT(path)
+		if err != nil {
+			log.Fatalf("Error when reading %s: %v", path, err)
+		}
+		if merged.Header.Version != "" {
+			merged.Header.Version += ", "
+		}
+		merged.Header.Version += dat.Header.Name + " " + dat.Header.Version
+		merged.Games = append(merged.Games, dat.Games...)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := writeDAT(f, &merged); err != nil {
+		log.Fatalf("Error when writing %s: %v", *output, err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readDAT(path string) (*datFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	dat := &datFile{}
+	if err := xml.NewDecoder(f).Decode(dat); err != nil {
+		return nil, err
+	}
+	return dat, nil
+}
+
+func writeDAT(w io.Writer, dat *datFile) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	if err := encoder.Encode(dat); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -1,24 +1,32 @@
 package cartridge
 
+import "bytes"
+
 // MBC1 represents memory bank controller for MBC1 type.
 type MBC1 struct {
-	rom           []byte
-	romBankNumber byte
+	rom []byte
+	ram []byte
 
-	ram           []byte
-	ramBankNumber byte
+	// BANK1 register contains the lower 5 bits of ROM bank number and
+	// BANK2 register the upper 2 bits of ROM bank number or RAM bank number.
+	bank1 byte
+	bank2 byte
 
-	romBanking bool
+	// In mode 1 BANK2 affects also ROM area 0x0000-0x3fff and RAM banking.
+	mode       byte
 	ramEnabled bool
+
+	// Multicart cartridges (MBC1M) have BANK2 wired to ROM bank bits 4-5 instead of 5-6.
+	multicart bool
 }
 
 // NewMBC1 is a constructor for MBC1 type memory banking controller.
-func NewMBC1(rom []byte) *MBC1 {
+func NewMBC1(rom []byte, ramSize int) *MBC1 {
 	return &MBC1{
-		rom:           rom,
-		ram:           make([]byte, 0x8000),
-		romBankNumber: 1,
-		romBanking:    true,
+		rom:       rom,
+		ram:       make([]byte, ramSize),
+		bank1:     1,
+		multicart: isMulticart(rom),
 	}
 }
 
@@ -28,28 +36,20 @@ func (mbc *MBC1) WriteMemory(address uint16, value byte) {
 		// Any value with 0x0a in the lower 4 bits enables RAM and other values disable it
 		mbc.ramEnabled = value&0x0f == 0x0a
 	} else if address < 0x4000 {
-		// Set lower bits for ROM bank number
-		bank := (mbc.romBankNumber & 0xe0) | (value & 0x1f)
-		mbc.selectRomBank(bank)
-	} else if address < 0x6000 {
-		// Select RAM bank or set higher bits for ROM bank number
-		if mbc.romBanking {
-			bank := (mbc.romBankNumber & 0x1f) | (value & 0xe0)
-			mbc.selectRomBank(bank)
-		} else {
-			mbc.selectRamBank(value & 0x03)
+		// Set BANK1 register. Value 0 is mapped to 1 before combining with BANK2.
+		mbc.bank1 = value & 0x1f
+		if mbc.bank1 == 0 {
+			mbc.bank1 = 1
 		}
+	} else if address < 0x6000 {
+		// Set BANK2 register
+		mbc.bank2 = value & 0x03
 	} else if address < 0x8000 {
-		// Select ROM/RAM banking mode
-		mbc.romBanking = value&1 == 0
-		if mbc.romBanking {
-			mbc.selectRamBank(0)
-		} else {
-			mbc.selectRomBank(mbc.romBankNumber & 0x1f)
-		}
+		// Select banking mode
+		mbc.mode = value & 0x01
 	} else if address >= 0xa000 && address < 0xc000 {
 		// Write to RAM
-		if mbc.ramEnabled {
+		if mbc.ramEnabled && len(mbc.ram) > 0 {
 			mbc.ram[mbc.mapAddressToRam(address)] = value
 		}
 	}
@@ -58,35 +58,67 @@ func (mbc *MBC1) WriteMemory(address uint16, value byte) {
 // ReadMemory handles reads from memory for MBC1.
 func (mbc *MBC1) ReadMemory(address uint16) byte {
 	if address < 0x4000 {
-		// ROM bank 0
-		return mbc.rom[address]
+		// ROM bank 0, or bank selected by BANK2 in mode 1
+		bank := 0
+		if mbc.mode == 1 {
+			bank = mbc.upperBankBits()
+		}
+		return mbc.rom[mbc.mapAddressToRom(bank, address)]
 	} else if address < 0x8000 {
 		// Switchable ROM bank
-		return mbc.rom[mbc.mapAddressToRom(address)]
+		bank := mbc.upperBankBits() | int(mbc.bank1)
+		if mbc.multicart {
+			bank = mbc.upperBankBits() | int(mbc.bank1&0x0f)
+		}
+		return mbc.rom[mbc.mapAddressToRom(bank, address&0x3fff)]
 	} else if address >= 0xa000 && address < 0xc000 {
 		// Switchable RAM bank
+		if !mbc.ramEnabled || len(mbc.ram) == 0 {
+			return 0xff
+		}
 		return mbc.ram[mbc.mapAddressToRam(address)]
 	}
-	panic("Tried to read invalid memory address from MBC")
+	// Unmapped addresses read as open bus
+	return 0xff
 }
 
-func (mbc *MBC1) selectRomBank(bank byte) {
-	if bank == 0x00 || bank == 0x20 || bank == 0x40 || bank == 0x60 {
-		bank++
-	}
-	mbc.romBankNumber = bank
+// RAM returns contents of cartridge RAM.
+func (mbc *MBC1) RAM() []byte {
+	return mbc.ram
+}
+
+// RAMEnabled checks if cartridge RAM is currently accessible.
+func (mbc *MBC1) RAMEnabled() bool {
+	return mbc.ramEnabled
 }
 
-func (mbc *MBC1) selectRamBank(bank byte) {
-	mbc.ramBankNumber = bank
+// upperBankBits returns BANK2 register shifted to its position in ROM bank number.
+func (mbc *MBC1) upperBankBits() int {
+	if mbc.multicart {
+		return int(mbc.bank2) << 4
+	}
+	return int(mbc.bank2) << 5
 }
 
-func (mbc *MBC1) mapAddressToRom(address uint16) int {
-	bank := int(mbc.romBankNumber)
-	return int(address-0x4000) + (bank * 0x4000)
+// mapAddressToRom maps offset within bank to ROM address. Banks that don't exist wrap around.
+func (mbc *MBC1) mapAddressToRom(bank int, offset uint16) int {
+	return (int(offset) + (bank * 0x4000)) % len(mbc.rom)
 }
 
 func (mbc *MBC1) mapAddressToRam(address uint16) int {
-	bank := int(mbc.ramBankNumber)
-	return int(address-0xa000) + (bank * 0x2000)
+	bank := 0
+	if mbc.mode == 1 {
+		bank = int(mbc.bank2)
+	}
+	return (int(address-0xa000) + (bank * 0x2000)) % len(mbc.ram)
+}
+
+// isMulticart detects MBC1M multicarts by checking if 1MB ROM contains
+// Nintendo logo in the header of the second game at bank 0x10.
+func isMulticart(rom []byte) bool {
+	const secondHeader = 0x10 * 0x4000
+	if len(rom) != 0x100000 {
+		return false
+	}
+	return bytes.Equal(nintendoLogo, rom[secondHeader+0x104:secondHeader+0x134])
 }
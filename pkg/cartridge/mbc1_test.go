@@ -0,0 +1,101 @@
+package cartridge
+
+import "testing"
+
+// createBankedROM creates ROM where first byte of each bank contains the bank number.
+func createBankedROM(banks int) []byte {
+	rom := make([]byte, banks*0x4000)
+	for bank := 0; bank < banks; bank++ {
+		rom[bank*0x4000] = byte(bank)
+	}
+	return rom
+}
+
+func TestMBC1ROMBanking(t *testing.T) {
+	mbc := NewMBC1(createBankedROM(128), 0)
+	cases := []struct {
+		bank1, bank2 byte
+		expected     byte
+	}{
+		{0x00, 0, 0x01},
+		{0x01, 0, 0x01},
+		{0x1f, 0, 0x1f},
+		{0x20, 0, 0x01}, // Only lower 5 bits are used
+		{0x00, 1, 0x21},
+		{0x05, 2, 0x45},
+		{0x1f, 3, 0x7f},
+	}
+	for _, c := range cases {
+		mbc.WriteMemory(0x2000, c.bank1)
+		mbc.WriteMemory(0x4000, c.bank2)
+		if value := mbc.ReadMemory(0x4000); value != c.expected {
+			t.Errorf("BANK1=%x BANK2=%x should select bank %x, got %x", c.bank1, c.bank2, c.expected, value)
+		}
+	}
+}
+
+func TestMBC1Mode1AffectsLowerROMArea(t *testing.T) {
+	mbc := NewMBC1(createBankedROM(128), 0)
+	mbc.WriteMemory(0x4000, 0x02)
+	if value := mbc.ReadMemory(0x0000); value != 0x00 {
+		t.Errorf("Mode 0 should map bank 0 to lower area, got bank %x", value)
+	}
+	mbc.WriteMemory(0x6000, 0x01)
+	if value := mbc.ReadMemory(0x0000); value != 0x40 {
+		t.Errorf("Mode 1 should map bank 0x40 to lower area, got bank %x", value)
+	}
+}
+
+func TestMBC1BankWrapsByROMSize(t *testing.T) {
+	mbc := NewMBC1(createBankedROM(8), 0)
+	mbc.WriteMemory(0x2000, 0x0b)
+	if value := mbc.ReadMemory(0x4000); value != 0x03 {
+		t.Errorf("Bank 0x0b should wrap to bank 3 in 8 bank ROM, got %x", value)
+	}
+	mbc.WriteMemory(0x4000, 0x01)
+	mbc.WriteMemory(0x6000, 0x01)
+	if value := mbc.ReadMemory(0x0000); value != 0x00 {
+		t.Errorf("Bank 0x20 should wrap to bank 0 in 8 bank ROM, got %x", value)
+	}
+}
+
+func TestMBC1RAMBanking(t *testing.T) {
+	mbc := NewMBC1(createBankedROM(4), 0x8000)
+	mbc.WriteMemory(0x0000, 0x0a)
+	mbc.WriteMemory(0x4000, 0x02)
+	mbc.WriteMemory(0xa000, 0x42)
+	if value := mbc.ram[0]; value != 0x42 {
+		t.Errorf("Mode 0 should always use RAM bank 0")
+	}
+
+	mbc.WriteMemory(0x6000, 0x01)
+	mbc.WriteMemory(0xa000, 0x24)
+	if value := mbc.ram[2*0x2000]; value != 0x24 {
+		t.Errorf("Mode 1 should use RAM bank selected by BANK2")
+	}
+
+	mbc.WriteMemory(0x0000, 0x00)
+	if value := mbc.ReadMemory(0xa000); value != 0xff {
+		t.Errorf("Disabled RAM should read %x, got %x", 0xff, value)
+	}
+}
+
+func TestMBC1Multicart(t *testing.T) {
+	rom := createBankedROM(64)
+	copy(rom[0x10*0x4000+0x104:], nintendoLogo)
+	mbc := NewMBC1(rom, 0)
+	if !mbc.multicart {
+		t.Fatal("ROM should be detected as multicart")
+	}
+
+	mbc.WriteMemory(0x2000, 0x12)
+	mbc.WriteMemory(0x4000, 0x01)
+	if value := mbc.ReadMemory(0x4000); value != 0x12 {
+		t.Errorf("Multicart BANK1=0x12 BANK2=1 should select bank 0x12, got %x", value)
+	}
+	mbc.WriteMemory(0x6000, 0x01)
+	mbc.WriteMemory(0x4000, 0x02)
+	if value := mbc.ReadMemory(0x0000); value != 0x20 {
+		t.Errorf("Multicart mode 1 should map bank 0x20 to lower area, got %x", value)
+	}
+}
@@ -1,6 +1,7 @@
 package cartridge
 
 // MBC2 represents memory bank controller for MBC2 type.
+// MBC2 contains built-in RAM of 512 half-bytes.
 type MBC2 struct {
 	ram           []byte
 	rom           []byte
@@ -12,30 +13,34 @@ type MBC2 struct {
 func NewMBC2(rom []byte) *MBC2 {
 	return &MBC2{
 		rom:           rom,
-		ram:           make([]byte, 0x2000),
+		ram:           make([]byte, 0x200),
 		romBankNumber: 1,
 	}
 }
 
 // WriteMemory handles writes to MBC2.
 func (mbc *MBC2) WriteMemory(address uint16, value byte) {
-	if address < 0x2000 {
-		// Any value with 0x0a in the lower 4 bits enables RAM and other values disable it
-		mbc.ramEnabled = value&0x0f == 0x0a
-	} else if address < 0x4000 {
-		// 5th bit must be 1 to select ROM bank which is specified by the lower 4 bits.
-		if address&0x100 == 0x100 {
-			mbc.selectRomBank(value & 0xf)
+	if address < 0x4000 {
+		// Address bit 8 selects between RAMG and ROMB registers
+		if address&0x100 == 0 {
+			// Any value with 0x0a in the lower 4 bits enables RAM and other values disable it
+			mbc.ramEnabled = value&0x0f == 0x0a
+		} else {
+			// ROM bank is specified by the lower 4 bits and bank 0 is mapped to bank 1
+			mbc.romBankNumber = value & 0x0f
+			if mbc.romBankNumber == 0 {
+				mbc.romBankNumber = 1
+			}
 		}
-	} else if address >= 0xa000 && address < 0xa200 {
-		// Write to RAM
+	} else if address >= 0xa000 && address < 0xc000 {
+		// Write to RAM. Only lower 9 bits of address are used, so RAM is mirrored across the area.
 		if mbc.ramEnabled {
-			mbc.ram[address-0xa000] = value & 0xf
+			mbc.ram[address&0x1ff] = value & 0x0f
 		}
 	}
 }
 
-// ReadMemory handles reads from memory for MBC1.
+// ReadMemory handles reads from memory for MBC2.
 func (mbc *MBC2) ReadMemory(address uint16) byte {
 	if address < 0x4000 {
 		// ROM bank 0
@@ -43,21 +48,28 @@ func (mbc *MBC2) ReadMemory(address uint16) byte {
 	} else if address < 0x8000 {
 		// Switchable ROM bank
 		return mbc.rom[mbc.mapAddressToRom(address)]
-	} else if address >= 0xa000 && address < 0xa200 {
-		// Switchable RAM bank
-		return mbc.ram[address-0xa000] & 0xf
+	} else if address >= 0xa000 && address < 0xc000 {
+		// Built-in RAM, upper 4 bits are not connected and read as 1
+		if !mbc.ramEnabled {
+			return 0xff
+		}
+		return mbc.ram[address&0x1ff] | 0xf0
 	}
-	panic("Tried to read invalid memory address from MBC")
+	// Unmapped addresses read as open bus
+	return 0xff
 }
 
-func (mbc *MBC2) selectRomBank(bank byte) {
-	if bank == 0x00 || bank == 0x20 || bank == 0x40 || bank == 0x60 {
-		bank++
-	}
-	mbc.romBankNumber = bank
+// RAM returns contents of the built-in 512x4 bits RAM.
+func (mbc *MBC2) RAM() []byte {
+	return mbc.ram
+}
+
+// RAMEnabled checks if RAM is currently accessible.
+func (mbc *MBC2) RAMEnabled() bool {
+	return mbc.ramEnabled
 }
 
 func (mbc *MBC2) mapAddressToRom(address uint16) int {
 	bank := int(mbc.romBankNumber)
-	return int(address-0x4000) + (bank * 0x4000)
+	return (int(address-0x4000) + (bank * 0x4000)) % len(mbc.rom)
 }
@@ -0,0 +1,85 @@
+package cartridge
+
+import "testing"
+
+func TestMBC2RAMGRegister(t *testing.T) {
+	mbc := NewMBC2(createBankedROM(16))
+	cases := []struct {
+		address uint16
+		value   byte
+		enabled bool
+	}{
+		{0x0000, 0x0a, true},
+		{0x0000, 0x00, false},
+		{0x3eff, 0x1a, true},  // Upper bits of value are ignored
+		{0x2000, 0x0b, false}, // RAMG is decoded on whole 0x0000-0x3fff area
+		{0x00ff, 0x0a, true},
+		{0x0100, 0x00, true}, // Address bit 8 selects ROMB instead
+	}
+	for _, c := range cases {
+		mbc.WriteMemory(c.address, c.value)
+		if mbc.ramEnabled != c.enabled {
+			t.Errorf("Writing %x to %x should set RAM enabled to %t", c.value, c.address, c.enabled)
+		}
+	}
+}
+
+func TestMBC2ROMBRegister(t *testing.T) {
+	mbc := NewMBC2(createBankedROM(16))
+	cases := []struct {
+		address uint16
+		value   byte
+		bank    byte
+	}{
+		{0x2100, 0x05, 0x05},
+		{0x0100, 0x0f, 0x0f}, // ROMB is decoded on whole 0x0000-0x3fff area
+		{0x3fff, 0x03, 0x03},
+		{0x2100, 0x00, 0x01}, // Bank 0 is mapped to bank 1
+		{0x2100, 0x10, 0x01}, // Only lower 4 bits are used
+		{0x2100, 0x27, 0x07},
+		{0x2000, 0x09, 0x07}, // Address bit 8 selects RAMG instead
+	}
+	for _, c := range cases {
+		mbc.WriteMemory(c.address, c.value)
+		if value := mbc.ReadMemory(0x4000); value != c.bank {
+			t.Errorf("Writing %x to %x should select bank %x, got %x", c.value, c.address, c.bank, value)
+		}
+	}
+}
+
+func TestMBC2ROMBankWraps(t *testing.T) {
+	mbc := NewMBC2(createBankedROM(4))
+	mbc.WriteMemory(0x2100, 0x06)
+	if value := mbc.ReadMemory(0x4000); value != 0x02 {
+		t.Errorf("Bank 6 should wrap to bank 2 in 4 bank ROM, got %x", value)
+	}
+}
+
+func TestMBC2RAM(t *testing.T) {
+	mbc := NewMBC2(createBankedROM(4))
+	mbc.WriteMemory(0xa000, 0x05)
+	if value := mbc.ReadMemory(0xa000); value != 0xff {
+		t.Errorf("Disabled RAM should read %x, got %x", 0xff, value)
+	}
+
+	mbc.WriteMemory(0x0000, 0x0a)
+	mbc.WriteMemory(0xa000, 0x35)
+	if value := mbc.ReadMemory(0xa000); value != 0xf5 {
+		t.Errorf("Upper 4 bits of RAM should read as 1, expected %x, got %x", 0xf5, value)
+	}
+}
+
+func TestMBC2RAMMirroring(t *testing.T) {
+	mbc := NewMBC2(createBankedROM(4))
+	mbc.WriteMemory(0x0000, 0x0a)
+	mbc.WriteMemory(0xa1ff, 0x0c)
+	for _, address := range []uint16{0xa1ff, 0xa3ff, 0xb5ff, 0xbfff} {
+		if value := mbc.ReadMemory(address); value != 0xfc {
+			t.Errorf("RAM should be mirrored at %x, expected %x, got %x", address, 0xfc, value)
+		}
+	}
+	mbc.WriteMemory(0xbe00, 0x03)
+	if value := mbc.ReadMemory(0xa000); value != 0xf3 {
+		t.Errorf("Write to mirror should modify RAM at %x, expected %x, got %x", 0xa000, 0xf3, value)
+	}
+}
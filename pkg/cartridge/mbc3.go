@@ -0,0 +1,121 @@
+package cartridge
+
+// MBC3 represents memory bank controller for MBC3 type.
+type MBC3 struct {
+	rom           []byte
+	romBankNumber byte
+
+	ram           []byte
+	ramBankNumber byte
+
+	// Real time clock is nil for cartridges without timer
+	rtc        *RTC
+	ramEnabled bool
+}
+
+// NewMBC3 is a constructor for MBC3 type memory banking controller.
+// Parameter rtc should be nil if cartridge does not contain a real time clock.
+func NewMBC3(rom []byte, ramSize int, rtc *RTC) *MBC3 {
+	return &MBC3{
+		rom:           rom,
+		ram:           make([]byte, ramSize),
+		romBankNumber: 1,
+		rtc:           rtc,
+	}
+}
+
+// SetTimeSource sets source for current time of real time clock.
+// Source is ignored if cartridge does not contain a real time clock.
+func (mbc *MBC3) SetTimeSource(source TimeSource) {
+	if mbc.rtc != nil {
+		mbc.rtc.SetTimeSource(source)
+	}
+}
+
+// WriteMemory handles writes to MBC3.
+func (mbc *MBC3) WriteMemory(address uint16, value byte) {
+	if address < 0x2000 {
+		// Any value with 0x0a in the lower 4 bits enables RAM and RTC registers
+		mbc.ramEnabled = value&0x0f == 0x0a
+	} else if address < 0x4000 {
+		// Select ROM bank using the lower 7 bits. Bank 0 is mapped to bank 1.
+		bank := value & 0x7f
+		if bank == 0 {
+			bank = 1
+		}
+		mbc.romBankNumber = bank
+	} else if address < 0x6000 {
+		// Select RAM bank (0x00-0x03) or RTC register (0x08-0x0c)
+		mbc.ramBankNumber = value
+	} else if address < 0x8000 {
+		// Latch clock data
+		if mbc.rtc != nil {
+			mbc.rtc.Latch(value)
+		}
+	} else if address >= 0xa000 && address < 0xc000 {
+		if !mbc.ramEnabled {
+			return
+		}
+		if mbc.ramBankNumber <= 0x03 && len(mbc.ram) > 0 {
+			mbc.ram[mbc.mapAddressToRam(address)] = value
+		} else if mbc.ramBankNumber >= 0x08 && mbc.rtc != nil {
+			mbc.rtc.Write(mbc.ramBankNumber, value)
+		}
+	}
+}
+
+// ReadMemory handles reads from memory for MBC3.
+func (mbc *MBC3) ReadMemory(address uint16) byte {
+	if address < 0x4000 {
+		// ROM bank 0
+		return mbc.rom[address]
+	} else if address < 0x8000 {
+		// Switchable ROM bank
+		return mbc.rom[mbc.mapAddressToRom(address)]
+	} else if address >= 0xa000 && address < 0xc000 {
+		if !mbc.ramEnabled {
+			return 0xff
+		}
+		if mbc.ramBankNumber <= 0x03 && len(mbc.ram) > 0 {
+			return mbc.ram[mbc.mapAddressToRam(address)]
+		} else if mbc.ramBankNumber >= 0x08 && mbc.rtc != nil {
+			return mbc.rtc.Read(mbc.ramBankNumber)
+		}
+		return 0xff
+	}
+	// Unmapped addresses read as open bus
+	return 0xff
+}
+
+func (mbc *MBC3) mapAddressToRom(address uint16) int {
+	bank := int(mbc.romBankNumber)
+	return (int(address-0x4000) + (bank * 0x4000)) % len(mbc.rom)
+}
+
+func (mbc *MBC3) mapAddressToRam(address uint16) int {
+	bank := int(mbc.ramBankNumber)
+	return (int(address-0xa000) + (bank * 0x2000)) % len(mbc.ram)
+}
+
+// RAM returns contents of cartridge RAM.
+func (mbc *MBC3) RAM() []byte {
+	return mbc.ram
+}
+
+// RAMEnabled checks if cartridge RAM is currently accessible.
+func (mbc *MBC3) RAMEnabled() bool {
+	return mbc.ramEnabled
+}
+
+func (mbc *MBC3) saveClock() []byte {
+	if mbc.rtc == nil {
+		return nil
+	}
+	return mbc.rtc.encode()
+}
+
+func (mbc *MBC3) loadClock(data []byte) {
+	if mbc.rtc != nil {
+		mbc.rtc.decode(data)
+	}
+}
@@ -0,0 +1,126 @@
+package cartridge
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeTime struct {
+	now time.Time
+}
+
+func (f *fakeTime) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeTime) advance(d time.Duration) {
+	f.now = f.now.Add(d)
+}
+
+func initMBC3() (*MBC3, *fakeTime) {
+	clock := &fakeTime{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	rom := make([]byte, 0x8000*4)
+	mbc := NewMBC3(rom, 0x8000, NewRTC(clock))
+	mbc.WriteMemory(0x0000, 0x0a)
+	return mbc, clock
+}
+
+func latch(mbc *MBC3) {
+	mbc.WriteMemory(0x6000, 0x00)
+	mbc.WriteMemory(0x6000, 0x01)
+}
+
+func readRTC(mbc *MBC3, register byte) byte {
+	mbc.WriteMemory(0x4000, register)
+	return mbc.ReadMemory(0xa000)
+}
+
+func TestMBC3ROMBanking(t *testing.T) {
+	mbc, _ := initMBC3()
+	mbc.rom[0x4000*5] = 0x55
+
+	mbc.WriteMemory(0x2000, 0x05)
+	if value := mbc.ReadMemory(0x4000); value != 0x55 {
+		t.Errorf("Value at bank 5 should be %x, got %x", 0x55, value)
+	}
+	mbc.WriteMemory(0x2000, 0x00)
+	if mbc.romBankNumber != 1 {
+		t.Errorf("ROM bank 0 should map to bank 1, got %d", mbc.romBankNumber)
+	}
+}
+
+func TestMBC3RAMBanking(t *testing.T) {
+	mbc, _ := initMBC3()
+	mbc.WriteMemory(0x4000, 0x02)
+	mbc.WriteMemory(0xa010, 0x42)
+	mbc.WriteMemory(0x4000, 0x00)
+	if value := mbc.ReadMemory(0xa010); value != 0x00 {
+		t.Errorf("Value at RAM bank 0 should be %x, got %x", 0x00, value)
+	}
+	mbc.WriteMemory(0x4000, 0x02)
+	if value := mbc.ReadMemory(0xa010); value != 0x42 {
+		t.Errorf("Value at RAM bank 2 should be %x, got %x", 0x42, value)
+	}
+}
+
+func TestMBC3ClockAdvancesOnLatch(t *testing.T) {
+	mbc, clock := initMBC3()
+	clock.advance(2*24*time.Hour + 3*time.Hour + 4*time.Minute + 5*time.Second)
+
+	if value := readRTC(mbc, rtcSeconds); value != 0 {
+		t.Errorf("Seconds should be 0 before latching, got %d", value)
+	}
+	latch(mbc)
+
+	expected := map[byte]byte{rtcSeconds: 5, rtcMinutes: 4, rtcHours: 3, rtcDaysLow: 2, rtcDaysHigh: 0}
+	for register, want := range expected {
+		if value := readRTC(mbc, register); value != want {
+			t.Errorf("RTC register %x should be %d, got %d", register, want, value)
+		}
+	}
+}
+
+func TestMBC3ClockHalt(t *testing.T) {
+	mbc, clock := initMBC3()
+	mbc.WriteMemory(0x4000, rtcDaysHigh)
+	mbc.WriteMemory(0xa000, 0x40)
+	clock.advance(time.Hour)
+	latch(mbc)
+
+	if value := readRTC(mbc, rtcHours); value != 0 {
+		t.Errorf("Halted clock should not advance, got %d hours", value)
+	}
+	if value := readRTC(mbc, rtcDaysHigh); value != 0x40 {
+		t.Errorf("Halt flag should be set, got %x", value)
+	}
+}
+
+func TestMBC3ClockDayCarry(t *testing.T) {
+	mbc, clock := initMBC3()
+	clock.advance(513 * 24 * time.Hour)
+	latch(mbc)
+
+	if value := readRTC(mbc, rtcDaysLow); value != 1 {
+		t.Errorf("Day counter should wrap to 1, got %d", value)
+	}
+	if value := readRTC(mbc, rtcDaysHigh); value != 0x80 {
+		t.Errorf("Carry flag should be set after day overflow, got %x", value)
+	}
+}
+
+func TestMBC3ClockWrite(t *testing.T) {
+	mbc, clock := initMBC3()
+	mbc.WriteMemory(0x4000, rtcMinutes)
+	mbc.WriteMemory(0xa000, 59)
+	mbc.WriteMemory(0x4000, rtcSeconds)
+	mbc.WriteMemory(0xa000, 59)
+	clock.advance(time.Second)
+	latch(mbc)
+
+	if value := readRTC(mbc, rtcHours); value != 1 {
+		t.Errorf("Hours should be 1, got %d", value)
+	}
+	if value := readRTC(mbc, rtcMinutes); value != 0 {
+		t.Errorf("Minutes should be 0, got %d", value)
+	}
+}
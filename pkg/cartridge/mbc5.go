@@ -0,0 +1,110 @@
+package cartridge
+
+import "github.com/v4t/gomb/pkg/utils"
+
+// MBC5 represents memory bank controller for MBC5 type.
+type MBC5 struct {
+	rom           []byte
+	romBankNumber uint16
+
+	ram           []byte
+	ramBankNumber byte
+
+	ramEnabled bool
+
+	// Rumble cartridges use bit 3 of the RAM bank register for controlling the motor
+	hasRumble     bool
+	rumbleOn      bool
+	rumbleHandler func(on bool)
+}
+
+// NewMBC5 is a constructor for MBC5 type memory banking controller.
+func NewMBC5(rom []byte, ramSize int, hasRumble bool) *MBC5 {
+	return &MBC5{
+		rom:           rom,
+		ram:           make([]byte, ramSize),
+		romBankNumber: 1,
+		hasRumble:     hasRumble,
+	}
+}
+
+// SetRumbleHandler sets function that is called when rumble motor state changes.
+func (mbc *MBC5) SetRumbleHandler(handler func(on bool)) {
+	mbc.rumbleHandler = handler
+}
+
+// WriteMemory handles writes to MBC5.
+func (mbc *MBC5) WriteMemory(address uint16, value byte) {
+	if address < 0x2000 {
+		// Only value 0x0a enables RAM and other values disable it
+		mbc.ramEnabled = value == 0x0a
+	} else if address < 0x3000 {
+		// Set lower 8 bits for ROM bank number
+		mbc.romBankNumber = (mbc.romBankNumber & 0x100) | uint16(value)
+	} else if address < 0x4000 {
+		// Set 9th bit for ROM bank number
+		mbc.romBankNumber = (mbc.romBankNumber & 0xff) | uint16(value&1)<<8
+	} else if address < 0x6000 {
+		// Select RAM bank and control rumble motor
+		if mbc.hasRumble {
+			mbc.setRumble(utils.TestBit(value, 3))
+			mbc.ramBankNumber = value & 0x07
+		} else {
+			mbc.ramBankNumber = value & 0x0f
+		}
+	} else if address >= 0xa000 && address < 0xc000 {
+		// Write to RAM
+		if mbc.ramEnabled && len(mbc.ram) > 0 {
+			mbc.ram[mbc.mapAddressToRam(address)] = value
+		}
+	}
+}
+
+// ReadMemory handles reads from memory for MBC5.
+func (mbc *MBC5) ReadMemory(address uint16) byte {
+	if address < 0x4000 {
+		// ROM bank 0
+		return mbc.rom[address]
+	} else if address < 0x8000 {
+		// Switchable ROM bank
+		return mbc.rom[mbc.mapAddressToRom(address)]
+	} else if address >= 0xa000 && address < 0xc000 {
+		// Switchable RAM bank
+		if !mbc.ramEnabled || len(mbc.ram) == 0 {
+			return 0xff
+		}
+		return mbc.ram[mbc.mapAddressToRam(address)]
+	}
+	// Unmapped addresses read as open bus
+	return 0xff
+}
+
+func (mbc *MBC5) setRumble(on bool) {
+	if on == mbc.rumbleOn {
+		return
+	}
+	mbc.rumbleOn = on
+	if mbc.rumbleHandler != nil {
+		mbc.rumbleHandler(on)
+	}
+}
+
+func (mbc *MBC5) mapAddressToRom(address uint16) int {
+	bank := int(mbc.romBankNumber)
+	return (int(address-0x4000) + (bank * 0x4000)) % len(mbc.rom)
+}
+
+func (mbc *MBC5) mapAddressToRam(address uint16) int {
+	bank := int(mbc.ramBankNumber)
+	return (int(address-0xa000) + (bank * 0x2000)) % len(mbc.ram)
+}
+
+// RAM returns contents of cartridge RAM.
+func (mbc *MBC5) RAM() []byte {
+	return mbc.ram
+}
+
+// RAMEnabled checks if cartridge RAM is currently accessible.
+func (mbc *MBC5) RAMEnabled() bool {
+	return mbc.ramEnabled
+}
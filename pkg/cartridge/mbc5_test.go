@@ -0,0 +1,55 @@
+package cartridge
+
+import "testing"
+
+func TestMBC5ROMBanking(t *testing.T) {
+	rom := make([]byte, 0x4000*512)
+	rom[0x4000*0x1ab] = 0x55
+	rom[0] = 0x11
+	mbc := NewMBC5(rom, 0x20000, false)
+
+	mbc.WriteMemory(0x2000, 0xab)
+	mbc.WriteMemory(0x3000, 0x01)
+	if value := mbc.ReadMemory(0x4000); value != 0x55 {
+		t.Errorf("Value at bank 0x1ab should be %x, got %x", 0x55, value)
+	}
+
+	// Unlike in other controllers, bank 0 can be mapped to the switchable area
+	mbc.WriteMemory(0x2000, 0x00)
+	mbc.WriteMemory(0x3000, 0x00)
+	if value := mbc.ReadMemory(0x4000); value != 0x11 {
+		t.Errorf("Value at bank 0 should be %x, got %x", 0x11, value)
+	}
+}
+
+func TestMBC5RAMBanking(t *testing.T) {
+	mbc := NewMBC5(make([]byte, 0x8000), 0x20000, false)
+	mbc.WriteMemory(0x0000, 0x0a)
+	mbc.WriteMemory(0x4000, 0x0f)
+	mbc.WriteMemory(0xa000, 0x42)
+	mbc.WriteMemory(0x4000, 0x00)
+	if value := mbc.ReadMemory(0xa000); value != 0x00 {
+		t.Errorf("Value at RAM bank 0 should be %x, got %x", 0x00, value)
+	}
+	mbc.WriteMemory(0x4000, 0x0f)
+	if value := mbc.ReadMemory(0xa000); value != 0x42 {
+		t.Errorf("Value at RAM bank 15 should be %x, got %x", 0x42, value)
+	}
+}
+
+func TestMBC5Rumble(t *testing.T) {
+	mbc := NewMBC5(make([]byte, 0x8000), 0x20000, true)
+	var states []bool
+	mbc.SetRumbleHandler(func(on bool) { states = append(states, on) })
+
+	mbc.WriteMemory(0x4000, 0x09)
+	mbc.WriteMemory(0x4000, 0x0a)
+	mbc.WriteMemory(0x4000, 0x02)
+
+	if len(states) != 2 || !states[0] || states[1] {
+		t.Errorf("Rumble handler should be called with [true false], got %v", states)
+	}
+	if mbc.ramBankNumber != 0x02 {
+		t.Errorf("Rumble bit should not affect RAM bank, got bank %d", mbc.ramBankNumber)
+	}
+}
@@ -0,0 +1,155 @@
+package cartridge
+
+import "sync"
+
+// TiltSource provides accelerometer input for MBC7 cartridges.
+// Values range from -1 to 1 where 0 means that device is held level.
+type TiltSource interface {
+	Tilt() (x, y float64)
+}
+
+// Accelerometer is implemented by memory bank controllers that contain an accelerometer.
+type Accelerometer interface {
+	SetTiltSource(source TiltSource)
+}
+
+// TiltState is a tilt source which values are set manually, e.g. from keyboard input.
+// It is safe to set values while emulator is running in another goroutine.
+type TiltState struct {
+	mutex sync.Mutex
+	x, y  float64
+}
+
+// Set current tilt values.
+func (tilt *TiltState) Set(x, y float64) {
+	tilt.mutex.Lock()
+	defer tilt.mutex.Unlock()
+	tilt.x, tilt.y = x, y
+}
+
+// Tilt returns current tilt values.
+func (tilt *TiltState) Tilt() (x, y float64) {
+	tilt.mutex.Lock()
+	defer tilt.mutex.Unlock()
+	return tilt.x, tilt.y
+}
+
+// Accelerometer values when device is level, and the change caused by gravity when tilted 90 degrees.
+const (
+	accelerometerCenter  = 0x81d0
+	accelerometerGravity = 0x70
+)
+
+// MBC7 represents memory bank controller for MBC7 type.
+// Instead of RAM, MBC7 has a 2-axis accelerometer and a serial EEPROM mapped to 0xa000-0xafff.
+type MBC7 struct {
+	rom           []byte
+	romBankNumber byte
+
+	ramEnabled1 bool
+	ramEnabled2 bool
+
+	tilt     TiltSource
+	latchedX uint16
+	latchedY uint16
+	erased   bool
+
+	eeprom *eeprom
+}
+
+// NewMBC7 is a constructor for MBC7 type memory banking controller.
+func NewMBC7(rom []byte) *MBC7 {
+	return &MBC7{
+		rom:           rom,
+		romBankNumber: 1,
+		latchedX:      0x8000,
+		latchedY:      0x8000,
+		eeprom:        newEEPROM(),
+	}
+}
+
+// SetTiltSource sets source for accelerometer values.
+func (mbc *MBC7) SetTiltSource(source TiltSource) {
+	mbc.tilt = source
+}
+
+// WriteMemory handles writes to MBC7.
+func (mbc *MBC7) WriteMemory(address uint16, value byte) {
+	if address < 0x2000 {
+		mbc.ramEnabled1 = value == 0x0a
+	} else if address < 0x4000 {
+		mbc.romBankNumber = value & 0x7f
+	} else if address < 0x6000 {
+		mbc.ramEnabled2 = value == 0x40
+	} else if address >= 0xa000 && address < 0xb000 && mbc.RAMEnabled() {
+		// Registers are selected by address bits 4-7
+		switch (address >> 4) & 0x0f {
+		case 0x0:
+			// Writing 0x55 erases latched values
+			if value == 0x55 {
+				mbc.latchedX, mbc.latchedY = 0x8000, 0x8000
+				mbc.erased = true
+			}
+		case 0x1:
+			// Writing 0xaa after erase latches current accelerometer values
+			if value == 0xaa && mbc.erased {
+				mbc.latchAccelerometer()
+				mbc.erased = false
+			}
+		case 0x8:
+			mbc.eeprom.write(value)
+		}
+	}
+}
+
+// ReadMemory handles reads from memory for MBC7.
+func (mbc *MBC7) ReadMemory(address uint16) byte {
+	if address < 0x4000 {
+		// ROM bank 0
+		return mbc.rom[address]
+	} else if address < 0x8000 {
+		// Switchable ROM bank
+		return mbc.rom[mbc.mapAddressToRom(address)]
+	} else if address >= 0xa000 && address < 0xb000 && mbc.RAMEnabled() {
+		switch (address >> 4) & 0x0f {
+		case 0x2:
+			return byte(mbc.latchedX)
+		case 0x3:
+			return byte(mbc.latchedX >> 8)
+		case 0x4:
+			return byte(mbc.latchedY)
+		case 0x5:
+			return byte(mbc.latchedY >> 8)
+		case 0x6:
+			return 0x00
+		case 0x8:
+			return mbc.eeprom.read()
+		}
+	}
+	// Unmapped addresses read as open bus
+	return 0xff
+}
+
+// RAM returns contents of the EEPROM.
+func (mbc *MBC7) RAM() []byte {
+	return mbc.eeprom.data
+}
+
+// RAMEnabled checks if accelerometer and EEPROM are accessible.
+func (mbc *MBC7) RAMEnabled() bool {
+	return mbc.ramEnabled1 && mbc.ramEnabled2
+}
+
+func (mbc *MBC7) latchAccelerometer() {
+	var x, y float64
+	if mbc.tilt != nil {
+		x, y = mbc.tilt.Tilt()
+	}
+	mbc.latchedX = uint16(accelerometerCenter + int(x*accelerometerGravity))
+	mbc.latchedY = uint16(accelerometerCenter + int(y*accelerometerGravity))
+}
+
+func (mbc *MBC7) mapAddressToRom(address uint16) int {
+	bank := int(mbc.romBankNumber)
+	return (int(address-0x4000) + (bank * 0x4000)) % len(mbc.rom)
+}
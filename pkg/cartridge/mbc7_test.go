@@ -0,0 +1,134 @@
+package cartridge
+
+import "testing"
+
+type fakeTilt struct {
+	x, y float64
+}
+
+func (tilt *fakeTilt) Tilt() (x, y float64) {
+	return tilt.x, tilt.y
+}
+
+func initMBC7() *MBC7 {
+	mbc := NewMBC7(make([]byte, 0x8000))
+	mbc.WriteMemory(0x0000, 0x0a)
+	mbc.WriteMemory(0x4000, 0x40)
+	return mbc
+}
+
+func readAccelerometer(mbc *MBC7) (x, y uint16) {
+	x = uint16(mbc.ReadMemory(0xa020)) | uint16(mbc.ReadMemory(0xa030))<<8
+	y = uint16(mbc.ReadMemory(0xa040)) | uint16(mbc.ReadMemory(0xa050))<<8
+	return x, y
+}
+
+// sendEEPROM clocks given bits to EEPROM, most significant bit first.
+func sendEEPROM(mbc *MBC7, value uint32, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		di := byte(value>>uint(i)&1) << 1
+		mbc.WriteMemory(0xa080, 0x80|di)
+		mbc.WriteMemory(0xa080, 0xc0|di)
+	}
+}
+
+// receiveEEPROM clocks 16 bits out of EEPROM.
+func receiveEEPROM(mbc *MBC7) uint16 {
+	var value uint16
+	for i := 0; i < 16; i++ {
+		mbc.WriteMemory(0xa080, 0x80)
+		mbc.WriteMemory(0xa080, 0xc0)
+		value = value<<1 | uint16(mbc.ReadMemory(0xa080)&0x01)
+	}
+	return value
+}
+
+func TestMBC7Accelerometer(t *testing.T) {
+	mbc := initMBC7()
+	tilt := &fakeTilt{}
+	mbc.SetTiltSource(tilt)
+
+	steps := []struct {
+		x, y         float64
+		wantX, wantY uint16
+	}{
+		{0, 0, 0x81d0, 0x81d0},
+		{1, -1, 0x8240, 0x8160},
+		{-0.5, 0.5, 0x8198, 0x8208},
+	}
+	for _, step := range steps {
+		tilt.x, tilt.y = step.x, step.y
+		mbc.WriteMemory(0xa000, 0x55)
+		if x, y := readAccelerometer(mbc); x != 0x8000 || y != 0x8000 {
+			t.Errorf("Erased values should be 0x8000, got %x %x", x, y)
+		}
+		mbc.WriteMemory(0xa010, 0xaa)
+		if x, y := readAccelerometer(mbc); x != step.wantX || y != step.wantY {
+			t.Errorf("Tilt (%v, %v) should latch %x %x, got %x %x", step.x, step.y, step.wantX, step.wantY, x, y)
+		}
+	}
+
+	// Latched values don't change until latched again
+	tilt.x = 1
+	if x, _ := readAccelerometer(mbc); x != 0x8198 {
+		t.Errorf("Latched x should stay %x, got %x", 0x8198, x)
+	}
+}
+
+func TestMBC7RegistersDisabled(t *testing.T) {
+	mbc := NewMBC7(make([]byte, 0x8000))
+	mbc.WriteMemory(0x0000, 0x0a)
+	if value := mbc.ReadMemory(0xa060); value != 0xff {
+		t.Errorf("Registers should read %x when second enable is not set, got %x", 0xff, value)
+	}
+}
+
+func TestMBC7EEPROM(t *testing.T) {
+	mbc := initMBC7()
+
+	// Writes are ignored until enabled with EWEN
+	sendEEPROM(mbc, 0x1<<10|0x1<<8|0x05, 11)
+	sendEEPROM(mbc, 0x1234, 16)
+	mbc.WriteMemory(0xa080, 0x00)
+	if word := mbc.eeprom.word(0x05); word != 0xffff {
+		t.Errorf("Write without EWEN should be ignored, got %x", word)
+	}
+
+	sendEEPROM(mbc, 0x1<<10|0xc0, 11)
+	mbc.WriteMemory(0xa080, 0x00)
+	sendEEPROM(mbc, 0x1<<10|0x1<<8|0x05, 11)
+	sendEEPROM(mbc, 0x1234, 16)
+	mbc.WriteMemory(0xa080, 0x00)
+	sendEEPROM(mbc, 0x1<<10|0x1<<8|0x06, 11)
+	sendEEPROM(mbc, 0xabcd, 16)
+	mbc.WriteMemory(0xa080, 0x00)
+
+	// Read outputs a dummy zero bit followed by sequential words
+	sendEEPROM(mbc, 0x1<<10|0x2<<8|0x05, 11)
+	if do := mbc.ReadMemory(0xa080) & 0x01; do != 0 {
+		t.Errorf("Dummy bit should be 0, got %d", do)
+	}
+	if word := receiveEEPROM(mbc); word != 0x1234 {
+		t.Errorf("Word 0x05 should be %x, got %x", 0x1234, word)
+	}
+	if word := receiveEEPROM(mbc); word != 0xabcd {
+		t.Errorf("Word 0x06 should be %x, got %x", 0xabcd, word)
+	}
+	mbc.WriteMemory(0xa080, 0x00)
+
+	if mbc.RAM()[0x0a] != 0x34 || mbc.RAM()[0x0b] != 0x12 {
+		t.Errorf("EEPROM contents should be exposed as RAM, got %x", mbc.RAM()[0x0a:0x0c])
+	}
+}
+
+func TestMBC7SaveData(t *testing.T) {
+	cart := newTestCartridge(t, createROM(0x22, 2))
+	mbc := cart.mbc.(*MBC7)
+	mbc.eeprom.setWord(0x10, 0xbeef)
+
+	restored := newTestCartridge(t, createROM(0x22, 2))
+	restored.loadSaveData(cart.saveData())
+	if word := restored.mbc.(*MBC7).eeprom.word(0x10); word != 0xbeef {
+		t.Errorf("EEPROM should be restored from save data, got %x", word)
+	}
+}
@@ -0,0 +1,156 @@
+package cartridge
+
+import (
+	"bytes"
+
+	"github.com/v4t/gomb/pkg/utils"
+)
+
+// MMM01 represents memory bank controller used in multi-game compilation cartridges.
+// At startup the last 32KB of ROM containing the game selection menu is mapped.
+// Menu configures the outer ROM and RAM banks and then locks the mapping,
+// after which the selected game sees an MBC1 compatible controller.
+type MMM01 struct {
+	rom []byte
+	ram []byte
+
+	mapped     bool
+	ramEnabled bool
+
+	// Bank registers. Middle and high bits can be set only before mapping is locked.
+	romBankLow  byte
+	romBankMid  byte
+	romBankHigh byte
+	ramBankLow  byte
+	ramBankHigh byte
+
+	// Mask of ROM bank low bits 1-4 that are fixed by the menu and can't be changed by the game
+	romBankMask byte
+	ramBankMask byte
+
+	mode       byte
+	modeLocked bool
+}
+
+// NewMMM01 is a constructor for MMM01 type memory banking controller.
+func NewMMM01(rom []byte, ramSize int) *MMM01 {
+	return &MMM01{
+		rom: rom,
+		ram: make([]byte, ramSize),
+	}
+}
+
+// WriteMemory handles writes to MMM01.
+func (mbc *MMM01) WriteMemory(address uint16, value byte) {
+	if address < 0x2000 {
+		mbc.ramEnabled = value&0x0f == 0x0a
+		if !mbc.mapped {
+			mbc.ramBankMask = (value >> 4) & 0x03
+			mbc.mapped = utils.TestBit(value, 6)
+		}
+	} else if address < 0x4000 {
+		fixed := mbc.romBankMask << 1
+		mbc.romBankLow = (mbc.romBankLow & fixed) | (value & 0x1f &^ fixed)
+		if !mbc.mapped {
+			mbc.romBankMid = (value >> 5) & 0x03
+		}
+	} else if address < 0x6000 {
+		mbc.ramBankLow = (mbc.ramBankLow & mbc.ramBankMask) | (value & 0x03 &^ mbc.ramBankMask)
+		if !mbc.mapped {
+			mbc.ramBankHigh = (value >> 2) & 0x03
+			mbc.romBankHigh = (value >> 4) & 0x03
+			mbc.modeLocked = utils.TestBit(value, 6)
+		}
+	} else if address < 0x8000 {
+		if !mbc.modeLocked {
+			mbc.mode = value & 0x01
+		}
+		if !mbc.mapped {
+			mbc.romBankMask = (value >> 2) & 0x0f
+		}
+	} else if address >= 0xa000 && address < 0xc000 {
+		if mbc.ramEnabled && len(mbc.ram) > 0 {
+			mbc.ram[mbc.mapAddressToRam(address)] = value
+		}
+	}
+}
+
+// ReadMemory handles reads from memory for MMM01.
+func (mbc *MMM01) ReadMemory(address uint16) byte {
+	if address < 0x4000 {
+		return mbc.rom[mbc.mapAddressToRom(mbc.lowerBank(), address)]
+	} else if address < 0x8000 {
+		return mbc.rom[mbc.mapAddressToRom(mbc.upperBank(), address&0x3fff)]
+	} else if address >= 0xa000 && address < 0xc000 {
+		if !mbc.ramEnabled || len(mbc.ram) == 0 {
+			return 0xff
+		}
+		return mbc.ram[mbc.mapAddressToRam(address)]
+	}
+	// Unmapped addresses read as open bus
+	return 0xff
+}
+
+// RAM returns contents of cartridge RAM.
+func (mbc *MMM01) RAM() []byte {
+	return mbc.ram
+}
+
+// RAMEnabled checks if cartridge RAM is currently accessible.
+func (mbc *MMM01) RAMEnabled() bool {
+	return mbc.ramEnabled
+}
+
+// outerBank returns ROM bank bits that are set by the menu.
+func (mbc *MMM01) outerBank() int {
+	return int(mbc.romBankHigh)<<7 | int(mbc.romBankMid)<<5 | int(mbc.romBankLow&(mbc.romBankMask<<1))
+}
+
+// lowerBank returns ROM bank mapped to 0x0000-0x3fff.
+func (mbc *MMM01) lowerBank() int {
+	if !mbc.mapped {
+		// Menu is located at the last 32KB of ROM
+		return len(mbc.rom)/0x4000 - 2
+	}
+	return mbc.outerBank()
+}
+
+// upperBank returns ROM bank mapped to 0x4000-0x7fff.
+func (mbc *MMM01) upperBank() int {
+	if !mbc.mapped {
+		return len(mbc.rom)/0x4000 - 1
+	}
+	// Bank 0 of the game is mapped to bank 1 like in MBC1
+	inner := int(mbc.romBankLow &^ (mbc.romBankMask << 1))
+	if inner == 0 {
+		inner = 1
+	}
+	return mbc.outerBank() | inner
+}
+
+func (mbc *MMM01) mapAddressToRom(bank int, offset uint16) int {
+	return (int(offset) + (bank * 0x4000)) % len(mbc.rom)
+}
+
+func (mbc *MMM01) mapAddressToRam(address uint16) int {
+	bank := int(mbc.ramBankHigh)<<2 | int(mbc.ramBankLow)
+	if mbc.mode == 0 {
+		bank &^= 0x03 &^ int(mbc.ramBankMask)
+	}
+	return (int(address-0xa000) + (bank * 0x2000)) % len(mbc.ram)
+}
+
+// isMMM01 detects MMM01 cartridges, which have the menu header at the last 32KB of ROM.
+// Any ROM may have MMM01 type byte at that offset by chance, so the rest of the menu header
+// must be valid and declare the size of the whole ROM.
+func isMMM01(rom []byte) bool {
+	if len(rom) < 0x8000 {
+		return false
+	}
+	menu := rom[len(rom)-0x8000:]
+	if mbcType := menu[0x147]; mbcType < 0x0b || mbcType > 0x0d {
+		return false
+	}
+	header := NewHeader(menu)
+	return bytes.Equal(menu[0x104:0x134], nintendoLogo) && header.HeaderChecksumValid && header.ROMSize() == len(rom)
+}
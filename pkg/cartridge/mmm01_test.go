@@ -0,0 +1,81 @@
+package cartridge
+
+import "testing"
+
+// setMenuHeader writes valid MMM01 menu header to the last 32KB of ROM.
+func setMenuHeader(rom []byte, sizeCode byte) {
+	menu := rom[len(rom)-0x8000:]
+	copy(menu[0x104:], nintendoLogo)
+	menu[0x147] = 0x0b
+	menu[0x148] = sizeCode
+	menu[0x14d] = headerChecksum(menu)
+}
+
+func TestMMM01BootsToMenu(t *testing.T) {
+	rom := createBankedROM(64)
+	setMenuHeader(rom, 0x05)
+	if !isMMM01(rom) {
+		t.Fatal("ROM should be detected as MMM01")
+	}
+	mbc := NewMMM01(rom, 0)
+	if lower, upper := mbc.ReadMemory(0x0000), mbc.ReadMemory(0x4000); lower != 62 || upper != 63 {
+		t.Errorf("Menu banks 62 and 63 should be mapped, got %d and %d", lower, upper)
+	}
+}
+
+func TestMMM01MapsSelectedGame(t *testing.T) {
+	mbc := NewMMM01(createBankedROM(64), 0)
+
+	// Menu selects 32KB game at bank 0x20 and locks the mapping
+	mbc.WriteMemory(0x2000, 0x20)
+	mbc.WriteMemory(0x6000, 0x3c)
+	mbc.WriteMemory(0x0000, 0x40)
+	if lower, upper := mbc.ReadMemory(0x0000), mbc.ReadMemory(0x4000); lower != 0x20 || upper != 0x21 {
+		t.Errorf("Game banks 0x20 and 0x21 should be mapped, got %x and %x", lower, upper)
+	}
+
+	// Game can only switch banks within its own area
+	mbc.WriteMemory(0x2000, 0x1f)
+	if value := mbc.ReadMemory(0x4000); value != 0x21 {
+		t.Errorf("Game bank 0x1f should map to bank 0x21, got %x", value)
+	}
+	mbc.WriteMemory(0x2000, 0x00)
+	mbc.WriteMemory(0x6000, 0x00)
+	if value := mbc.ReadMemory(0x0000); value != 0x20 {
+		t.Errorf("Locked mapping should not change, got bank %x", value)
+	}
+}
+
+func TestMMM01Detection(t *testing.T) {
+	rom := createROM(0x01, 4)
+	setMenuHeader(rom, 0x01)
+	cart := newTestCartridge(t, rom)
+	if _, ok := cart.mbc.(*MMM01); !ok || cart.Header.CartridgeType != 0x0b {
+		t.Errorf("ROM with valid menu header should be loaded as MMM01, got %T", cart.mbc)
+	}
+
+	// Menu header with wrong checksum, missing logo or wrong ROM size is not MMM01
+	invalid := []func(menu []byte){
+		func(menu []byte) { menu[0x14d]++ },
+		func(menu []byte) { menu[0x104] = 0x00 },
+		func(menu []byte) { menu[0x148] = 0x02; menu[0x14d] = headerChecksum(menu) },
+	}
+	for i, corrupt := range invalid {
+		rom := createROM(0x01, 4)
+		setMenuHeader(rom, 0x01)
+		corrupt(rom[len(rom)-0x8000:])
+		if isMMM01(rom) {
+			t.Errorf("Invalid menu header %d should not be detected as MMM01", i)
+		}
+	}
+
+	// MBC1 ROM may have MMM01 type byte at menu header offset by chance
+	for mbcType := byte(0x0b); mbcType <= 0x0d; mbcType++ {
+		rom := createROM(0x01, 4)
+		rom[len(rom)-0x8000+0x147] = mbcType
+		cart := newTestCartridge(t, rom)
+		if _, ok := cart.mbc.(*MBC1); !ok {
+			t.Errorf("MBC1 ROM with %x at menu type offset should be loaded as MBC1, got %T", mbcType, cart.mbc)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package cartridge
+
+import "github.com/v4t/gomb/pkg/utils"
+
+// PocketCamera represents memory bank controller of Game Boy Camera (Pocket Camera).
+// In addition to 128KB of RAM, the cartridge contains an image sensor which registers
+// are mapped to RAM area when bit 4 of RAM bank register is set.
+// Captured images are written as tile data to RAM bank 0 starting from 0xa100.
+type PocketCamera struct {
+	rom           []byte
+	romBankNumber byte
+
+	ram           []byte
+	ramBankNumber byte
+	ramEnabled    bool
+
+	registerMode bool
+	registers    []byte
+	source       ImageSource
+}
+
+// NewPocketCamera is a constructor for Pocket Camera memory banking controller.
+func NewPocketCamera(rom []byte) *PocketCamera {
+	return &PocketCamera{
+		rom:           rom,
+		ram:           make([]byte, 0x20000),
+		romBankNumber: 1,
+		registers:     make([]byte, cameraRegisters),
+	}
+}
+
+// SetImageSource sets source for images captured by the sensor.
+func (mbc *PocketCamera) SetImageSource(source ImageSource) {
+	mbc.source = source
+}
+
+// WriteMemory handles writes to Pocket Camera.
+func (mbc *PocketCamera) WriteMemory(address uint16, value byte) {
+	if address < 0x2000 {
+		mbc.ramEnabled = value&0x0f == 0x0a
+	} else if address < 0x4000 {
+		// Select ROM bank. Bank 0 is mapped to bank 1.
+		mbc.romBankNumber = value & 0x3f
+		if mbc.romBankNumber == 0 {
+			mbc.romBankNumber = 1
+		}
+	} else if address < 0x6000 {
+		// Bit 4 maps camera registers to RAM area, lower bits select RAM bank
+		mbc.registerMode = utils.TestBit(value, 4)
+		mbc.ramBankNumber = value & 0x0f
+	} else if address >= 0xa000 && address < 0xc000 {
+		if mbc.registerMode {
+			mbc.writeRegister(address, value)
+		} else if mbc.ramEnabled {
+			mbc.ram[mbc.mapAddressToRam(address)] = value
+		}
+	}
+}
+
+// ReadMemory handles reads from memory for Pocket Camera.
+func (mbc *PocketCamera) ReadMemory(address uint16) byte {
+	if address < 0x4000 {
+		// ROM bank 0
+		return mbc.rom[address]
+	} else if address < 0x8000 {
+		// Switchable ROM bank
+		return mbc.rom[mbc.mapAddressToRom(address)]
+	} else if address >= 0xa000 && address < 0xc000 {
+		if mbc.registerMode {
+			// Only control register can be read, others read as zero
+			if address&0x7f == cameraControl {
+				return mbc.registers[cameraControl]
+			}
+			return 0x00
+		}
+		// RAM can be read even when writes are disabled
+		return mbc.ram[mbc.mapAddressToRam(address)]
+	}
+	// Unmapped addresses read as open bus
+	return 0xff
+}
+
+// RAM returns contents of cartridge RAM.
+func (mbc *PocketCamera) RAM() []byte {
+	return mbc.ram
+}
+
+// RAMEnabled checks if cartridge RAM is currently writable.
+func (mbc *PocketCamera) RAMEnabled() bool {
+	return mbc.ramEnabled
+}
+
+// writeRegister writes to camera registers, which are mirrored every 0x80 bytes.
+// Setting bit 0 of control register starts a capture. Capture is completed immediately,
+// so the busy bit games poll is already cleared when it is read.
+func (mbc *PocketCamera) writeRegister(address uint16, value byte) {
+	index := int(address & 0x7f)
+	if index >= cameraRegisters {
+		return
+	}
+	if index != cameraControl {
+		mbc.registers[index] = value
+		return
+	}
+	mbc.registers[cameraControl] = value & 0x06
+	if utils.TestBit(value, 0) {
+		copy(mbc.ram[0x100:], capture(mbc.source, mbc.registers))
+	}
+}
+
+func (mbc *PocketCamera) mapAddressToRom(address uint16) int {
+	bank := int(mbc.romBankNumber)
+	return (int(address-0x4000) + (bank * 0x4000)) % len(mbc.rom)
+}
+
+func (mbc *PocketCamera) mapAddressToRam(address uint16) int {
+	bank := int(mbc.ramBankNumber)
+	return (int(address-0xa000) + (bank * 0x2000)) % len(mbc.ram)
+}
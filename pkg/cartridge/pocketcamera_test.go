@@ -0,0 +1,150 @@
+package cartridge
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestImage writes PNG image which left half is black and right half white.
+func writeTestImage(t *testing.T) string {
+	img := image.NewGray(image.Rect(0, 0, 256, 224))
+	for y := 0; y < 224; y++ {
+		for x := 128; x < 256; x++ {
+			img.SetGray(x, y, color.Gray{Y: 0xff})
+		}
+	}
+	dir, err := ioutil.TempDir("", "gomb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "image.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// initCamera returns camera in register mode with neutral exposure and evenly spaced dithering thresholds.
+func initCamera(t *testing.T) *PocketCamera {
+	source, err := LoadImageFile(writeTestImage(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mbc := NewPocketCamera(make([]byte, 0x8000))
+	mbc.SetImageSource(source)
+	mbc.WriteMemory(0x4000, 0x10)
+	mbc.WriteMemory(0xa002, 0x03)
+	mbc.WriteMemory(0xa003, 0x00)
+	for i := uint16(0); i < 16; i++ {
+		mbc.WriteMemory(0xa006+i*3, 0x40)
+		mbc.WriteMemory(0xa007+i*3, 0x80)
+		mbc.WriteMemory(0xa008+i*3, 0xc0)
+	}
+	return mbc
+}
+
+// readShades returns 2-bit shades of first row of tiles 0 and 15 from RAM.
+func readShades(mbc *PocketCamera) (left, right byte) {
+	mbc.WriteMemory(0x4000, 0x00)
+	left = mbc.ReadMemory(0xa100)&0x01 | (mbc.ReadMemory(0xa101)&0x01)<<1
+	right = mbc.ReadMemory(0xa1f0)&0x01 | (mbc.ReadMemory(0xa1f1)&0x01)<<1
+	return left, right
+}
+
+func TestPocketCameraRegisterMode(t *testing.T) {
+	mbc := NewPocketCamera(make([]byte, 0x8000))
+	mbc.WriteMemory(0x0000, 0x0a)
+	mbc.WriteMemory(0x4000, 0x0f)
+	mbc.WriteMemory(0xa000, 0x42)
+
+	mbc.WriteMemory(0x4000, 0x10)
+	mbc.WriteMemory(0xa004, 0x42)
+	if value := mbc.ReadMemory(0xa000); value != 0x00 {
+		t.Errorf("Control register should read %x, got %x", 0x00, value)
+	}
+	if value := mbc.ReadMemory(0xa004); value != 0x00 {
+		t.Errorf("Write only registers should read %x, got %x", 0x00, value)
+	}
+	if mbc.registers[cameraVoltage] != 0x42 {
+		t.Errorf("Register 4 should be %x, got %x", 0x42, mbc.registers[cameraVoltage])
+	}
+
+	mbc.WriteMemory(0x4000, 0x0f)
+	if value := mbc.ReadMemory(0xa000); value != 0x42 {
+		t.Errorf("RAM bank 15 should be mapped after register mode, got %x", value)
+	}
+	mbc.WriteMemory(0x0000, 0x00)
+	if value := mbc.ReadMemory(0xa000); value != 0x42 {
+		t.Errorf("RAM should be readable when disabled, got %x", value)
+	}
+}
+
+func TestPocketCameraCapture(t *testing.T) {
+	mbc := initCamera(t)
+	mbc.WriteMemory(0xa000, 0x01)
+	if value := mbc.ReadMemory(0xa000); value&0x01 != 0 {
+		t.Errorf("Capture should not be busy after completion, got %x", value)
+	}
+	if left, right := readShades(mbc); left != 3 || right != 0 {
+		t.Errorf("Black and white pixels should have shades 3 and 0, got %d and %d", left, right)
+	}
+}
+
+func TestPocketCameraExposure(t *testing.T) {
+	mbc := initCamera(t)
+	mbc.WriteMemory(0xa002, 0x00)
+	mbc.WriteMemory(0xa003, 0x40)
+	mbc.WriteMemory(0xa000, 0x01)
+	if left, right := readShades(mbc); left != 3 || right != 3 {
+		t.Errorf("Short exposure should produce dark image, got shades %d and %d", left, right)
+	}
+}
+
+func TestPocketCameraInvert(t *testing.T) {
+	mbc := initCamera(t)
+	mbc.WriteMemory(0xa004, 0x08)
+	mbc.WriteMemory(0xa000, 0x01)
+	if left, right := readShades(mbc); left != 0 || right != 3 {
+		t.Errorf("Inverted image should have shades 0 and 3, got %d and %d", left, right)
+	}
+}
+
+func TestPocketCameraEdgeEnhancement(t *testing.T) {
+	pixels := make([]float64, CameraWidth*CameraHeight)
+	for i := range pixels {
+		if i%CameraWidth >= CameraWidth/2 {
+			pixels[i] = 0x80
+		}
+	}
+	registers := make([]byte, cameraRegisters)
+	registers[cameraEdge] = 0x20
+	registers[cameraVoltage] = 0x20
+
+	result := enhanceEdges(pixels, registers)
+	if dark := result[CameraWidth/2-1]; dark >= 0 {
+		t.Errorf("Dark side of edge should be darkened, got %v", dark)
+	}
+	if light := result[CameraWidth/2]; light <= 0x80 {
+		t.Errorf("Light side of edge should be lightened, got %v", light)
+	}
+	if flat := result[0]; flat != 0 {
+		t.Errorf("Flat area should not change, got %v", flat)
+	}
+
+	// Vertical enhancement doesn't affect vertical edges
+	registers[cameraEdge] = 0x40
+	if value := enhanceEdges(pixels, registers)[CameraWidth/2]; value != 0x80 {
+		t.Errorf("Vertical enhancement should not change vertical edge, got %v", value)
+	}
+}
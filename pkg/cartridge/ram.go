@@ -0,0 +1,58 @@
+package cartridge
+
+// RAMChange describes a byte that differs between two RAM snapshots.
+type RAMChange struct {
+	Offset int
+	Old    byte
+	New    byte
+}
+
+// RAMSnapshot returns a copy of external RAM contents.
+// Nil is returned for cartridges without RAM.
+func (cart *Cartridge) RAMSnapshot() []byte {
+	ram, ok := cart.mbc.(ExternalRAM)
+	if !ok || len(ram.RAM()) == 0 {
+		return nil
+	}
+	return append([]byte{}, ram.RAM()...)
+}
+
+// DiffRAM compares snapshot to current external RAM contents and returns changed bytes.
+func (cart *Cartridge) DiffRAM(snapshot []byte) []RAMChange {
+	return DiffRAM(snapshot, cart.RAMSnapshot())
+}
+
+// DiffRAM returns bytes that differ between two RAM snapshots.
+// If snapshots are of different size, missing bytes are compared as zero.
+func DiffRAM(old, new []byte) []RAMChange {
+	size := len(old)
+	if len(new) > size {
+		size = len(new)
+	}
+	var changes []RAMChange
+	for i := 0; i < size; i++ {
+		var o, n byte
+		if i < len(old) {
+			o = old[i]
+		}
+		if i < len(new) {
+			n = new[i]
+		}
+		if o != n {
+			changes = append(changes, RAMChange{Offset: i, Old: o, New: n})
+		}
+	}
+	return changes
+}
+
+// LoadRAM replaces external RAM contents. Data must be exactly the size of cartridge RAM.
+// Loaded RAM is written to save file on next flush.
+func (cart *Cartridge) LoadRAM(data []byte) error {
+	ram, ok := cart.mbc.(ExternalRAM)
+	if !ok || len(ram.RAM()) != len(data) {
+		return &RAMSizeError{Expected: len(cart.RAMSnapshot()), Actual: len(data)}
+	}
+	copy(ram.RAM(), data)
+	cart.dirty = true
+	return nil
+}
@@ -0,0 +1,55 @@
+package cartridge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRAMSnapshotAndDiff(t *testing.T) {
+	cart := newTestCartridge(t, createROM(0x03, 2))
+	snapshot := cart.RAMSnapshot()
+	if len(snapshot) != 0x8000 {
+		t.Fatalf("Snapshot should contain %d bytes, got %d", 0x8000, len(snapshot))
+	}
+
+	cart.Write(0x0000, 0x0a)
+	cart.Write(0xa010, 0x42)
+	cart.Write(0x6000, 0x01)
+	cart.Write(0x4000, 0x01)
+	cart.Write(0xa000, 0x24)
+
+	expected := []RAMChange{{Offset: 0x0010, Old: 0, New: 0x42}, {Offset: 0x2000, Old: 0, New: 0x24}}
+	if changes := cart.DiffRAM(snapshot); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Changes should be %v, got %v", expected, changes)
+	}
+	if snapshot[0x10] != 0 {
+		t.Error("Snapshot should not change when RAM is written")
+	}
+}
+
+func TestLoadRAM(t *testing.T) {
+	cart := newTestCartridge(t, createROM(0x03, 2))
+	data := make([]byte, 0x8000)
+	data[0x123] = 0x42
+	if err := cart.LoadRAM(data); err != nil {
+		t.Fatal(err)
+	}
+	cart.Write(0x0000, 0x0a)
+	if value := cart.Read(0xa123); value != 0x42 {
+		t.Errorf("Loaded RAM value should be %x, got %x", 0x42, value)
+	}
+
+	if err := cart.LoadRAM(make([]byte, 0x2000)); err == nil {
+		t.Error("Loading RAM of wrong size should cause error")
+	} else if sizeErr, ok := err.(*RAMSizeError); !ok || sizeErr.Expected != 0x8000 {
+		t.Errorf("Error should be RAMSizeError expecting %d bytes, got %v", 0x8000, err)
+	}
+
+	romOnly := newTestCartridge(t, createROM(0x00, 2))
+	if romOnly.RAMSnapshot() != nil {
+		t.Error("Cartridge without RAM should have nil snapshot")
+	}
+	if err := romOnly.LoadRAM(data); err == nil {
+		t.Error("Loading RAM to cartridge without RAM should cause error")
+	}
+}
@@ -1,22 +1,46 @@
 package cartridge
 
 // ROM handles games that don't need a MBC but are mapped to memory directly.
+// Cartridge may optionally contain up to 8KB of RAM.
 type ROM struct {
 	data []byte
+	ram  []byte
 }
 
 // NewROM is a constructor.
-func NewROM(rom []byte) *ROM {
+func NewROM(rom []byte, ramSize int) *ROM {
 	return &ROM{
 		data: rom,
+		ram:  make([]byte, ramSize),
 	}
 }
 
 // WriteMemory handles writes to ROM.
-// Since there is no memory bank controller, writes are not allowed.
-func (rom *ROM) WriteMemory(address uint16, value byte) { }
+// Since there is no memory bank controller, only writes to RAM are allowed.
+func (rom *ROM) WriteMemory(address uint16, value byte) {
+	if address >= 0xa000 && address < 0xc000 && len(rom.ram) > 0 {
+		rom.ram[int(address-0xa000)%len(rom.ram)] = value
+	}
+}
 
 // ReadMemory handles reads from ROM.
 func (rom *ROM) ReadMemory(address uint16) byte {
-	return rom.data[address]
+	if address < 0x8000 && int(address) < len(rom.data) {
+		return rom.data[address]
+	} else if address >= 0xa000 && address < 0xc000 && len(rom.ram) > 0 {
+		return rom.ram[int(address-0xa000)%len(rom.ram)]
+	}
+	// Unmapped addresses read as open bus
+	return 0xff
+}
+
+// RAM returns contents of cartridge RAM.
+func (rom *ROM) RAM() []byte {
+	return rom.ram
+}
+
+// RAMEnabled checks if cartridge RAM is currently accessible.
+// Without memory bank controller RAM is always accessible.
+func (rom *ROM) RAMEnabled() bool {
+	return true
 }
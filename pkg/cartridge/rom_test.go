@@ -0,0 +1,31 @@
+package cartridge
+
+import "testing"
+
+func TestROMIgnoresBankSwitching(t *testing.T) {
+	cart := newTestCartridge(t, createROM(0x00, 2))
+	if _, ok := cart.mbc.(*ROM); !ok {
+		t.Fatalf("ROM only cartridge should use ROM mapper, got %T", cart.mbc)
+	}
+	cart.mbc.(*ROM).data[0x4000] = 0x11
+	cart.Write(0x2000, 0x05)
+	if value := cart.Read(0x4000); value != 0x11 {
+		t.Errorf("Bank switch writes should be ignored, expected %x, got %x", 0x11, value)
+	}
+	if value := cart.Read(0xa000); value != 0xff {
+		t.Errorf("Cartridge without RAM should read %x, got %x", 0xff, value)
+	}
+}
+
+func TestROMWithRAM(t *testing.T) {
+	rom := createROM(0x09, 2)
+	rom[0x149] = 0x02
+	cart := newTestCartridge(t, rom)
+	if !cart.Battery {
+		t.Error("ROM+RAM+BATTERY cartridge should have battery")
+	}
+	cart.Write(0xa123, 0x42)
+	if value := cart.Read(0xa123); value != 0x42 {
+		t.Errorf("RAM value should be %x, got %x", 0x42, value)
+	}
+}
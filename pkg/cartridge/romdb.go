@@ -0,0 +1,286 @@
+package cartridge
+
+import (
+	"bufio"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"image/color"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DumpStatus tells whether a known ROM dump is considered a correct copy of the cartridge.
+type DumpStatus int
+
+// Dump statuses in No-Intro DAT files.
+const (
+	DumpGood DumpStatus = iota
+	DumpVerified
+	DumpBad
+	DumpOverdump
+)
+
+// String returns name of the dump status.
+func (status DumpStatus) String() string {
+	switch status {
+	case DumpVerified:
+		return "verified"
+	case DumpBad:
+		return "bad dump"
+	case DumpOverdump:
+		return "overdump"
+	}
+	return "good"
+}
+
+// Dump contains information of a known ROM dump.
+type Dump struct {
+	Name   string
+	Region string
+	Size   int
+	CRC32  uint32
+	SHA1   string
+	Status DumpStatus
+}
+
+// Override contains per-game settings that replace values derived from cartridge header.
+type Override struct {
+	// Cartridge type used instead of the one in header, for games with incorrect header
+	CartridgeType    byte
+	HasCartridgeType bool
+	// Default palette for the game, nil for emulator default
+	Palette []color.RGBA
+}
+
+// ROMDatabase identifies ROM dumps by their checksums.
+type ROMDatabase struct {
+	mutex     sync.RWMutex
+	byCRC32   map[uint32][]Dump
+	overrides map[string]Override
+}
+
+//go:generate go run ./internal/gendat -o romdb/gb.dat $NOINTRO_DAT
+
+// embeddedDAT contains known dumps, generated from No-Intro DAT with go generate.
+//
+//go:embed romdb/gb.dat
+var embeddedDAT string
+
+// embeddedOverrides contains per-game overrides in the format read by LoadOverrides.
+//
+//go:embed romdb/overrides.txt
+var embeddedOverrides string
+
+// Database is used by NewCartridge for identifying loaded ROMs. It contains dumps and overrides
+// embedded in the package, and more can be added with LoadDAT and LoadOverrides.
+var Database = mustLoadEmbedded()
+
+// NewROMDatabase creates an empty ROM database.
+func NewROMDatabase() *ROMDatabase {
+	return &ROMDatabase{
+		byCRC32:   map[uint32][]Dump{},
+		overrides: map[string]Override{},
+	}
+}
+
+// LoadDATFile adds dumps from No-Intro DAT file to database.
+func (db *ROMDatabase) LoadDATFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return db.LoadDAT(f)
+}
+
+// datFile is the subset of No-Intro (Logiqx XML) DAT format used by the database.
+type datFile struct {
+	Games []struct {
+		Name string `xml:"name,attr"`
+		ROMs []struct {
+			Size   int    `xml:"size,attr"`
+			CRC    string `xml:"crc,attr"`
+			SHA1   string `xml:"sha1,attr"`
+			Status string `xml:"status,attr"`
+		} `xml:"rom"`
+	} `xml:"game"`
+}
+
+// LoadDAT adds dumps from No-Intro DAT to database.
+func (db *ROMDatabase) LoadDAT(r io.Reader) error {
+	var dat datFile
+	if err := xml.NewDecoder(r).Decode(&dat); err != nil {
+		return fmt.Errorf("invalid DAT file: %v", err)
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	for _, game := range dat.Games {
+		for _, rom := range game.ROMs {
+			crc, err := strconv.ParseUint(rom.CRC, 16, 32)
+			if err != nil {
+				return fmt.Errorf("invalid CRC32 %q for %s", rom.CRC, game.Name)
+			}
+			dump := Dump{
+				Name:   game.Name,
+				Region: regionFromName(game.Name),
+				Size:   rom.Size,
+				CRC32:  uint32(crc),
+				SHA1:   strings.ToLower(rom.SHA1),
+				Status: dumpStatus(game.Name, rom.Status),
+			}
+			db.byCRC32[dump.CRC32] = append(db.byCRC32[dump.CRC32], dump)
+		}
+	}
+	return nil
+}
+
+// LoadOverridesFile adds per-game overrides from file to database.
+func (db *ROMDatabase) LoadOverridesFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return db.LoadOverrides(f)
+}
+
+// LoadOverrides adds per-game overrides to database. Each line contains SHA-1 of ROM followed by
+// settings: type=XX replaces cartridge type and palette=RRGGBB,RRGGBB,RRGGBB,RRGGBB sets default
+// palette from lightest to darkest color. Empty lines and lines starting with # are ignored.
+func (db *ROMDatabase) LoadOverrides(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		sha1, override, err := parseOverride(text)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		db.SetOverride(sha1, override)
+	}
+	return scanner.Err()
+}
+
+// Lookup finds dump by checksums. Dumps are matched by CRC32, and by SHA-1 when DAT contains it.
+func (db *ROMDatabase) Lookup(crc uint32, sha1 string) (Dump, bool) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	for _, dump := range db.byCRC32[crc] {
+		if dump.SHA1 == "" || dump.SHA1 == strings.ToLower(sha1) {
+			return dump, true
+		}
+	}
+	return Dump{}, false
+}
+
+// SetOverride sets per-game override for ROM with given SHA-1.
+func (db *ROMDatabase) SetOverride(sha1 string, override Override) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.overrides[strings.ToLower(sha1)] = override
+}
+
+// Override returns per-game override for ROM with given SHA-1.
+func (db *ROMDatabase) Override(sha1 string) (Override, bool) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	override, ok := db.overrides[strings.ToLower(sha1)]
+	return override, ok
+}
+
+// Checksums returns CRC32 and hex encoded SHA-1 of ROM.
+func Checksums(rom []byte) (uint32, string) {
+	sum := sha1.Sum(rom)
+	return crc32.ChecksumIEEE(rom), hex.EncodeToString(sum[:])
+}
+
+// regionFromName returns region from No-Intro name, which is the first parenthesized part.
+// E.g. region of "Tetris (World) (Rev 1)" is "World".
+func regionFromName(name string) string {
+	start := strings.Index(name, "(")
+	if start < 0 {
+		return ""
+	}
+	end := strings.Index(name[start:], ")")
+	if end < 0 {
+		return ""
+	}
+	return name[start+1 : start+end]
+}
+
+// dumpStatus returns status from DAT status attribute, or from dump flags in name.
+func dumpStatus(name string, status string) DumpStatus {
+	if status == "baddump" || strings.Contains(name, "[b]") {
+		return DumpBad
+	} else if strings.Contains(name, "[o]") {
+		return DumpOverdump
+	} else if status == "verified" {
+		return DumpVerified
+	}
+	return DumpGood
+}
+
+// parseOverride parses SHA-1 and settings from a line of overrides file.
+func parseOverride(text string) (string, Override, error) {
+	var override Override
+	fields := strings.Fields(text)
+	sha1 := strings.ToLower(fields[0])
+	if _, err := hex.DecodeString(sha1); err != nil || len(sha1) != 40 {
+		return "", override, fmt.Errorf("invalid SHA-1 %q", fields[0])
+	}
+	for _, field := range fields[1:] {
+		setting := strings.SplitN(field, "=", 2)
+		if len(setting) != 2 {
+			return "", override, fmt.Errorf("invalid setting %q", field)
+		}
+		switch setting[0] {
+		case "type":
+			value, err := strconv.ParseUint(setting[1], 16, 8)
+			if err != nil {
+				return "", override, fmt.Errorf("invalid cartridge type %q", setting[1])
+			}
+			override.CartridgeType = byte(value)
+			override.HasCartridgeType = true
+		case "palette":
+			colors := strings.Split(setting[1], ",")
+			if len(colors) != 4 {
+				return "", override, fmt.Errorf("palette should have 4 colors, got %d", len(colors))
+			}
+			override.Palette = make([]color.RGBA, len(colors))
+			for i, c := range colors {
+				value, err := strconv.ParseUint(c, 16, 24)
+				if err != nil || len(c) != 6 {
+					return "", override, fmt.Errorf("invalid color %q", c)
+				}
+				override.Palette[i] = color.RGBA{byte(value >> 16), byte(value >> 8), byte(value), 0xff}
+			}
+		default:
+			return "", override, fmt.Errorf("unknown setting %q", setting[0])
+		}
+	}
+	return sha1, override, nil
+}
+
+// mustLoadEmbedded creates database from embedded DAT and overrides.
+func mustLoadEmbedded() *ROMDatabase {
+	db := NewROMDatabase()
+	if err := db.LoadDAT(strings.NewReader(embeddedDAT)); err != nil {
+		panic(err)
+	}
+	if err := db.LoadOverrides(strings.NewReader(embeddedOverrides)); err != nil {
+		panic(err)
+	}
+	return db
+}
@@ -0,0 +1,141 @@
+package cartridge
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// createDAT returns DAT with given ROM as verified dump and another bad dump.
+func createDAT(rom []byte) string {
+	crc, sha1 := Checksums(rom)
+	return fmt.Sprintf(`<?xml version="1.0"?>
+<datafile>
+	<header><name>Test</name></header>
+	<game name="Test Game (USA, Europe) (Rev 1)">
+		<description>Test Game (USA, Europe) (Rev 1)</description>
+		<rom name="Test Game (USA, Europe) (Rev 1).gb" size="%d" crc="%08X" sha1="%s" status="verified"/>
+	</game>
+	<game name="Broken Game (Japan)">
+		<rom name="Broken Game (Japan).gb" size="32768" crc="12345678" status="baddump"/>
+	</game>
+</datafile>`, len(rom), crc, strings.ToUpper(sha1))
+}
+
+func TestChecksums(t *testing.T) {
+	crc, sha1 := Checksums([]byte("abc"))
+	if crc != 0x352441c2 {
+		t.Errorf("CRC32 should be %08x, got %08x", 0x352441c2, crc)
+	}
+	if sha1 != "a9993e364706816aba3e25717850c26c9cd0d89d" {
+		t.Errorf("SHA-1 should be a9993e364706816aba3e25717850c26c9cd0d89d, got %s", sha1)
+	}
+}
+
+func TestROMDatabaseLookup(t *testing.T) {
+	rom := createROM(0x00, 2)
+	db := NewROMDatabase()
+	if err := db.LoadDAT(strings.NewReader(createDAT(rom))); err != nil {
+		t.Fatal(err)
+	}
+
+	crc, sha1 := Checksums(rom)
+	dump, ok := db.Lookup(crc, sha1)
+	if !ok {
+		t.Fatal("ROM should be found from database")
+	}
+	if dump.Name != "Test Game (USA, Europe) (Rev 1)" || dump.Region != "USA, Europe" || dump.Status != DumpVerified {
+		t.Errorf("Dump should be verified Test Game for USA, Europe, got %+v", dump)
+	}
+	if _, ok := db.Lookup(crc, "0000000000000000000000000000000000000000"); ok {
+		t.Error("Dump with matching CRC32 but different SHA-1 should not be found")
+	}
+
+	// Dumps without SHA-1 in DAT are matched by CRC32 only
+	dump, ok = db.Lookup(0x12345678, sha1)
+	if !ok || dump.Status != DumpBad || dump.Region != "Japan" {
+		t.Errorf("Bad dump for Japan should be found by CRC32, got %+v", dump)
+	}
+
+	if err := db.LoadDAT(strings.NewReader("<datafile>")); err == nil {
+		t.Error("Malformed DAT should cause error")
+	}
+}
+
+func TestCartridgeIdentification(t *testing.T) {
+	rom := createROM(0x00, 2)
+	if err := Database.LoadDAT(strings.NewReader(createDAT(rom))); err != nil {
+		t.Fatal(err)
+	}
+	cart := newTestCartridge(t, rom)
+	if cart.Dump == nil || cart.Dump.Name != "Test Game (USA, Europe) (Rev 1)" {
+		t.Fatalf("Cartridge should be identified as Test Game, got %+v", cart.Dump)
+	}
+	if summary := cart.String(); !strings.Contains(summary, "Known dump:      Test Game (USA, Europe) (Rev 1)") {
+		t.Errorf("Summary should contain dump name, got\n%s", summary)
+	}
+
+	unknown := newTestCartridge(t, createROM(0x01, 2))
+	if unknown.Dump != nil {
+		t.Errorf("Unknown ROM should not be identified, got %+v", unknown.Dump)
+	}
+}
+
+func TestCartridgeOverride(t *testing.T) {
+	rom := createROM(0x01, 4)
+	rom[0x134] = 'O'
+	_, sha1 := Checksums(rom)
+	palette := []color.RGBA{{0xff, 0xff, 0xff, 0xff}, {0xaa, 0xaa, 0xaa, 0xff}, {0x55, 0x55, 0x55, 0xff}, {0, 0, 0, 0xff}}
+	Database.SetOverride(sha1, Override{CartridgeType: 0x1b, HasCartridgeType: true, Palette: palette})
+
+	cart := newTestCartridge(t, rom)
+	if _, ok := cart.mbc.(*MBC5); !ok {
+		t.Errorf("Overridden cartridge type should use MBC5, got %T", cart.mbc)
+	}
+	if !cart.Battery {
+		t.Error("Overridden cartridge type should have battery")
+	}
+	if len(cart.Override.Palette) != 4 {
+		t.Errorf("Cartridge should have palette override, got %v", cart.Override.Palette)
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	text := `
+# Comment
+0123456789ABCDEF0123456789ABCDEF01234567 type=1b palette=ffffff,aaaaaa,555555,000000
+89abcdef0123456789abcdef0123456789abcdef	palette=e0f8d0,88c070,346856,081820
+`
+	db := NewROMDatabase()
+	if err := db.LoadOverrides(strings.NewReader(text)); err != nil {
+		t.Fatal(err)
+	}
+	override, ok := db.Override("0123456789abcdef0123456789abcdef01234567")
+	if !ok || !override.HasCartridgeType || override.CartridgeType != 0x1b {
+		t.Errorf("Override should replace cartridge type with 0x1b, got %+v", override)
+	}
+	if len(override.Palette) != 4 || override.Palette[1] != (color.RGBA{0xaa, 0xaa, 0xaa, 0xff}) {
+		t.Errorf("Override should contain palette, got %v", override.Palette)
+	}
+	override, ok = db.Override("89abcdef0123456789abcdef0123456789abcdef")
+	if !ok || override.HasCartridgeType || override.Palette[0] != (color.RGBA{0xe0, 0xf8, 0xd0, 0xff}) {
+		t.Errorf("Override should only contain palette, got %+v", override)
+	}
+
+	invalid := []string{
+		"0123 type=1b",
+		"0123456789abcdef0123456789abcdef01234567 type=1ff",
+		"0123456789abcdef0123456789abcdef01234567 palette=ffffff,000000",
+		"0123456789abcdef0123456789abcdef01234567 palette=ffffff,aaaaaa,555555,00000g",
+		"0123456789abcdef0123456789abcdef01234567 mapper=1b",
+		"0123456789abcdef0123456789abcdef01234567 type",
+	}
+	for _, line := range invalid {
+		if err := db.LoadOverrides(strings.NewReader("\n" + line)); err == nil {
+			t.Errorf("Override %q should cause error", line)
+		} else if !strings.HasPrefix(err.Error(), "line 2") {
+			t.Errorf("Error should contain line number, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package cartridge
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/v4t/gomb/pkg/utils"
+)
+
+// TimeSource provides current time for the real time clock.
+type TimeSource interface {
+	Now() time.Time
+}
+
+// SystemTime is a time source backed by the host system clock.
+type SystemTime struct{}
+
+// Now returns current system time.
+func (SystemTime) Now() time.Time {
+	return time.Now()
+}
+
+// RealTimeClock is implemented by memory bank controllers that contain a real time clock.
+type RealTimeClock interface {
+	SetTimeSource(source TimeSource)
+}
+
+// Addresses of real time clock registers when they are mapped to RAM area.
+const (
+	rtcSeconds  byte = 0x08
+	rtcMinutes  byte = 0x09
+	rtcHours    byte = 0x0a
+	rtcDaysLow  byte = 0x0b
+	rtcDaysHigh byte = 0x0c
+)
+
+// rtcRegisters contains a snapshot of clock counter values.
+type rtcRegisters struct {
+	seconds byte
+	minutes byte
+	hours   byte
+	days    uint16
+	halted  bool
+	carry   bool
+}
+
+// RTC represents the real time clock found in some MBC3 cartridges.
+type RTC struct {
+	source     TimeSource
+	lastUpdate time.Time
+
+	current rtcRegisters
+	latched rtcRegisters
+
+	latchValue byte
+}
+
+// NewRTC is a constructor for real time clock driven by given time source.
+func NewRTC(source TimeSource) *RTC {
+	return &RTC{
+		source:     source,
+		lastUpdate: source.Now(),
+		latchValue: 0xff,
+	}
+}
+
+// SetTimeSource replaces time source of the clock.
+// Time elapsed with previous source is counted before switching to new one.
+func (rtc *RTC) SetTimeSource(source TimeSource) {
+	rtc.update()
+	rtc.source = source
+	rtc.lastUpdate = source.Now()
+}
+
+// Latch handles writes to latch clock data register.
+// Writing 0x00 followed by 0x01 copies current time to the readable RTC registers.
+func (rtc *RTC) Latch(value byte) {
+	if rtc.latchValue == 0x00 && value == 0x01 {
+		rtc.update()
+		rtc.latched = rtc.current
+	}
+	rtc.latchValue = value
+}
+
+// Read latched value of given RTC register.
+func (rtc *RTC) Read(register byte) byte {
+	switch register {
+	case rtcSeconds:
+		return rtc.latched.seconds
+	case rtcMinutes:
+		return rtc.latched.minutes
+	case rtcHours:
+		return rtc.latched.hours
+	case rtcDaysLow:
+		return byte(rtc.latched.days & 0xff)
+	case rtcDaysHigh:
+		return daysHighValue(rtc.latched)
+	}
+	return 0xff
+}
+
+// Write value directly to given RTC register.
+func (rtc *RTC) Write(register byte, value byte) {
+	rtc.update()
+	switch register {
+	case rtcSeconds:
+		rtc.current.seconds = value & 0x3f
+		// Writing seconds resets the sub-second counter
+		rtc.lastUpdate = rtc.source.Now()
+	case rtcMinutes:
+		rtc.current.minutes = value & 0x3f
+	case rtcHours:
+		rtc.current.hours = value & 0x1f
+	case rtcDaysLow:
+		rtc.current.days = (rtc.current.days & 0x100) | uint16(value)
+	case rtcDaysHigh:
+		rtc.current.days = (rtc.current.days & 0xff) | uint16(value&1)<<8
+		rtc.current.halted = utils.TestBit(value, 6)
+		rtc.current.carry = utils.TestBit(value, 7)
+	}
+}
+
+// update advances clock counters by the time elapsed since previous update.
+func (rtc *RTC) update() {
+	now := rtc.source.Now()
+	if rtc.current.halted {
+		rtc.lastUpdate = now
+		return
+	}
+	elapsed := int64(now.Sub(rtc.lastUpdate) / time.Second)
+	if elapsed <= 0 {
+		return
+	}
+	rtc.lastUpdate = rtc.lastUpdate.Add(time.Duration(elapsed) * time.Second)
+
+	regs := &rtc.current
+	total := int64(regs.seconds) + int64(regs.minutes)*60 + int64(regs.hours)*3600 + elapsed
+	days := int64(regs.days) + total/86400
+	total %= 86400
+
+	regs.hours = byte(total / 3600)
+	regs.minutes = byte(total / 60 % 60)
+	regs.seconds = byte(total % 60)
+	if days > 0x1ff {
+		// Day counter overflow sets carry bit which stays set until cleared by a write
+		regs.carry = true
+		days &= 0x1ff
+	}
+	regs.days = uint16(days)
+}
+
+// rtcSaveSize is the size of clock data appended to save files.
+// Layout is compatible with the format used by BGB and VBA-M.
+const rtcSaveSize = 48
+
+// encode current clock state to save file format.
+// Current and latched registers are stored as 32-bit values followed by a 64-bit unix timestamp.
+func (rtc *RTC) encode() []byte {
+	rtc.update()
+	data := make([]byte, rtcSaveSize)
+	for i, regs := range []rtcRegisters{rtc.current, rtc.latched} {
+		values := []byte{regs.seconds, regs.minutes, regs.hours, byte(regs.days & 0xff), daysHighValue(regs)}
+		for j, value := range values {
+			binary.LittleEndian.PutUint32(data[(i*5+j)*4:], uint32(value))
+		}
+	}
+	binary.LittleEndian.PutUint64(data[40:], uint64(rtc.lastUpdate.Unix()))
+	return data
+}
+
+// decode clock state from save file format.
+// Time passed since the save was made is added to the clock.
+func (rtc *RTC) decode(data []byte) {
+	if len(data) < 44 {
+		return
+	}
+	for i, regs := range []*rtcRegisters{&rtc.current, &rtc.latched} {
+		value := func(j int) byte {
+			return byte(binary.LittleEndian.Uint32(data[(i*5+j)*4:]))
+		}
+		regs.seconds = value(0)
+		regs.minutes = value(1)
+		regs.hours = value(2)
+		regs.days = uint16(value(3)) | uint16(value(4)&1)<<8
+		regs.halted = utils.TestBit(value(4), 6)
+		regs.carry = utils.TestBit(value(4), 7)
+	}
+	// Older files only contain a 32-bit timestamp
+	var timestamp int64
+	if len(data) >= rtcSaveSize {
+		timestamp = int64(binary.LittleEndian.Uint64(data[40:]))
+	} else {
+		timestamp = int64(binary.LittleEndian.Uint32(data[40:]))
+	}
+	rtc.lastUpdate = time.Unix(timestamp, 0)
+	rtc.update()
+}
+
+// daysHighValue packs day counter bit 8, halt flag and carry flag to a single register value.
+func daysHighValue(regs rtcRegisters) byte {
+	value := byte(regs.days>>8) & 1
+	if regs.halted {
+		value = utils.SetBit(value, 6)
+	}
+	if regs.carry {
+		value = utils.SetBit(value, 7)
+	}
+	return value
+}
@@ -0,0 +1,86 @@
+package cartridge
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// clockController is implemented by memory bank controllers that may contain a clock,
+// which state is stored after RAM contents in save file.
+type clockController interface {
+	saveClock() []byte
+	loadClock(data []byte)
+}
+
+// SavePath returns default save file path for given ROM file.
+// Save files use the same name as the ROM file with .sav extension.
+func SavePath(romFile string) string {
+	return strings.TrimSuffix(romFile, filepath.Ext(romFile)) + ".sav"
+}
+
+// LoadSaveFile loads battery backed RAM contents from given file, and uses
+// the same file for later flushes. Missing save file is not considered an error.
+func (cart *Cartridge) LoadSaveFile(path string) error {
+	if !cart.Battery {
+		return nil
+	}
+	cart.savePath = path
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	cart.loadSaveData(data)
+	return nil
+}
+
+// Flush writes battery backed RAM contents to save file if RAM has been modified since previous flush.
+func (cart *Cartridge) Flush() error {
+	if cart.savePath == "" || !cart.dirty {
+		return nil
+	}
+	// Write to temporary file first so that a crash during write doesn't corrupt existing save
+	tmpPath := cart.savePath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, cart.saveData(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, cart.savePath); err != nil {
+		return err
+	}
+	cart.dirty = false
+	cart.flushPending = false
+	return nil
+}
+
+// FlushPending returns true if game has finished writing to RAM and it should be flushed.
+func (cart *Cartridge) FlushPending() bool {
+	return cart.flushPending
+}
+
+// saveData returns raw RAM dump, followed by clock data for cartridges with real time clock.
+func (cart *Cartridge) saveData() []byte {
+	ram, ok := cart.mbc.(ExternalRAM)
+	if !ok {
+		return nil
+	}
+	data := append([]byte{}, ram.RAM()...)
+	if cc, ok := cart.mbc.(clockController); ok {
+		data = append(data, cc.saveClock()...)
+	}
+	return data
+}
+
+// loadSaveData restores RAM and clock state from save data.
+func (cart *Cartridge) loadSaveData(data []byte) {
+	ram, ok := cart.mbc.(ExternalRAM)
+	if !ok {
+		return
+	}
+	n := copy(ram.RAM(), data)
+	if cc, ok := cart.mbc.(clockController); ok {
+		cc.loadClock(data[n:])
+	}
+}
@@ -0,0 +1,79 @@
+package cartridge
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSavePath(t *testing.T) {
+	if path := SavePath("roms/tetris.gb"); path != "roms/tetris.sav" {
+		t.Errorf("Save path should be roms/tetris.sav, got %s", path)
+	}
+}
+
+func TestBatteryRAMRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.sav")
+
+	cart := newTestCartridge(t, createROM(0x03, 2))
+	if !cart.Battery {
+		t.Fatal("MBC1+RAM+BATTERY cartridge should have battery")
+	}
+	if err := cart.LoadSaveFile(path); err != nil {
+		t.Fatalf("Missing save file should not cause error, got %v", err)
+	}
+	cart.Write(0x0000, 0x0a)
+	cart.Write(0xa123, 0x42)
+	if cart.FlushPending() {
+		t.Error("Flush should not be pending while RAM is enabled")
+	}
+	cart.Write(0x0000, 0x00)
+	if !cart.FlushPending() {
+		t.Error("Flush should be pending after RAM is disabled")
+	}
+	if err := cart.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newTestCartridge(t, createROM(0x03, 2))
+	if err := loaded.LoadSaveFile(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded.Write(0x0000, 0x0a)
+	if value := loaded.Read(0xa123); value != 0x42 {
+		t.Errorf("Loaded RAM value should be %x, got %x", 0x42, value)
+	}
+}
+
+func TestSaveDataWithoutBattery(t *testing.T) {
+	cart := newTestCartridge(t, createROM(0x02, 2))
+	if cart.Battery {
+		t.Error("MBC1+RAM cartridge should not have battery")
+	}
+}
+
+func TestClockSaveData(t *testing.T) {
+	clock := &fakeTime{now: time.Unix(1000000, 0)}
+	rtc := NewRTC(clock)
+	rtc.Write(rtcHours, 5)
+	data := rtc.encode()
+	if len(data) != rtcSaveSize {
+		t.Fatalf("Clock data should be %d bytes, got %d", rtcSaveSize, len(data))
+	}
+
+	clock.advance(time.Hour)
+	loaded := NewRTC(clock)
+	loaded.decode(data)
+	loaded.Latch(0)
+	loaded.Latch(1)
+	if value := loaded.Read(rtcHours); value != 6 {
+		t.Errorf("Clock should have advanced to 6 hours, got %d", value)
+	}
+}
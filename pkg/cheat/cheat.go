@@ -0,0 +1,133 @@
+// Package cheat decodes Game Genie and GameShark codes and applies them to running game.
+package cheat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Type of cheat device the code is meant for.
+type Type int
+
+// Supported cheat devices.
+const (
+	// GameGenie codes replace values read from ROM.
+	GameGenie Type = iota
+	// GameShark codes write values to RAM every frame.
+	GameShark
+)
+
+// String returns name of the cheat device.
+func (t Type) String() string {
+	if t == GameGenie {
+		return "Game Genie"
+	}
+	return "GameShark"
+}
+
+// Cheat is a decoded cheat code.
+type Cheat struct {
+	Code        string
+	Description string
+	Type        Type
+	Address     uint16
+	Value       byte
+	// Game Genie codes with compare byte apply only when the original value matches,
+	// so that the same address in other ROM banks is left untouched.
+	Compare    byte
+	HasCompare bool
+	Enabled    bool
+}
+
+// InvalidCodeError is returned when cheat code can't be decoded.
+type InvalidCodeError struct {
+	Code   string
+	Reason string
+}
+
+func (err *InvalidCodeError) Error() string {
+	return fmt.Sprintf("invalid cheat code %q: %s", err.Code, err.Reason)
+}
+
+// Decode detects code format and decodes it. Game Genie codes are written as ABC-DEF or ABC-DEF-GHI
+// and GameShark codes as 8 hex digits. Decoded cheats are enabled.
+func Decode(code string) (*Cheat, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if strings.Contains(code, "-") {
+		return DecodeGameGenie(code)
+	}
+	return DecodeGameShark(code)
+}
+
+// DecodeGameGenie decodes Game Genie code of format ABC-DEF or ABC-DEF-GHI, where
+// AB is the new value, FCDE the address with F inverted, and GI the compare value
+// rotated and scrambled. H is not used by the device.
+func DecodeGameGenie(code string) (*Cheat, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	parts := strings.Split(code, "-")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, &InvalidCodeError{Code: code, Reason: "Game Genie code should have 6 or 9 digits"}
+	}
+	digits := strings.Join(parts, "")
+	for _, part := range parts {
+		if len(part) != 3 {
+			return nil, &InvalidCodeError{Code: code, Reason: "Game Genie code should be in groups of 3 digits"}
+		}
+	}
+	n, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		return nil, &InvalidCodeError{Code: code, Reason: "code contains non-hexadecimal digits"}
+	}
+	if len(parts) == 3 {
+		n >>= 12
+	}
+
+	// Digits ABCDEF from most significant to least significant
+	digit := func(i uint) uint16 { return uint16(n>>(20-4*i)) & 0x0f }
+	cheat := &Cheat{
+		Code:    code,
+		Type:    GameGenie,
+		Value:   byte(n >> 16),
+		Address: (digit(5)^0x0f)<<12 | digit(2)<<8 | digit(3)<<4 | digit(4),
+		Enabled: true,
+	}
+	if cheat.Address >= 0x8000 {
+		return nil, &InvalidCodeError{Code: code, Reason: "Game Genie address is outside of ROM"}
+	}
+	if len(parts) == 3 {
+		ghi := parts[2]
+		compare, _ := strconv.ParseUint(ghi[0:1]+ghi[2:3], 16, 8)
+		c := byte(compare)
+		cheat.Compare = (c>>2 | c<<6) ^ 0xba
+		cheat.HasCompare = true
+	}
+	return cheat, nil
+}
+
+// DecodeGameShark decodes GameShark code of format TTVVLLHH, where TT is code type,
+// VV the value and HHLL the address. Only type 01 codes that write to RAM are supported.
+func DecodeGameShark(code string) (*Cheat, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 8 {
+		return nil, &InvalidCodeError{Code: code, Reason: "GameShark code should have 8 digits"}
+	}
+	n, err := strconv.ParseUint(code, 16, 32)
+	if err != nil {
+		return nil, &InvalidCodeError{Code: code, Reason: "code contains non-hexadecimal digits"}
+	}
+	if codeType := byte(n >> 24); codeType != 0x01 {
+		return nil, &InvalidCodeError{Code: code, Reason: fmt.Sprintf("unsupported GameShark code type %02X", codeType)}
+	}
+	cheat := &Cheat{
+		Code:    code,
+		Type:    GameShark,
+		Value:   byte(n >> 16),
+		Address: uint16(n>>8)&0xff | uint16(n)<<8,
+		Enabled: true,
+	}
+	if cheat.Address < 0x8000 {
+		return nil, &InvalidCodeError{Code: code, Reason: "GameShark address is not in RAM"}
+	}
+	return cheat, nil
+}
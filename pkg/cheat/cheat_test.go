@@ -0,0 +1,67 @@
+package cheat
+
+import "testing"
+
+func TestDecodeGameGenie(t *testing.T) {
+	cheat, err := Decode("00a-17b-c49")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cheat.Type != GameGenie {
+		t.Errorf("Code should be decoded as Game Genie code, got %v", cheat.Type)
+	}
+	if cheat.Value != 0x00 {
+		t.Errorf("Value should be %x, got %x", 0x00, cheat.Value)
+	}
+	if cheat.Address != 0x4a17 {
+		t.Errorf("Address should be %x, got %x", 0x4a17, cheat.Address)
+	}
+	if !cheat.HasCompare || cheat.Compare != 0xc8 {
+		t.Errorf("Compare value should be %x, got %x", 0xc8, cheat.Compare)
+	}
+}
+
+func TestDecodeGameGenieWithoutCompare(t *testing.T) {
+	cheat, err := Decode("3E1-23F")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cheat.Value != 0x3e || cheat.Address != 0x0123 || cheat.HasCompare {
+		t.Errorf("Code should write %x to %x without compare, got %+v", 0x3e, 0x0123, cheat)
+	}
+}
+
+func TestDecodeGameShark(t *testing.T) {
+	cheat, err := Decode("010238CD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cheat.Type != GameShark {
+		t.Errorf("Code should be decoded as GameShark code, got %v", cheat.Type)
+	}
+	if cheat.Value != 0x02 {
+		t.Errorf("Value should be %x, got %x", 0x02, cheat.Value)
+	}
+	if cheat.Address != 0xcd38 {
+		t.Errorf("Address should be %x, got %x", 0xcd38, cheat.Address)
+	}
+}
+
+func TestDecodeInvalidCodes(t *testing.T) {
+	codes := []string{
+		"00A-17B-C4", // Wrong group length
+		"00A-17B-C49-000",
+		"00A-17G-C49", // Not hexadecimal
+		"00A-170",     // Address 0x8a17 is outside ROM
+		"010238C",     // Too short
+		"910238CD",    // Unsupported type
+		"01023840",    // Address 0x4038 is ROM
+	}
+	for _, code := range codes {
+		if _, err := Decode(code); err == nil {
+			t.Errorf("Code %s should cause error", code)
+		} else if _, ok := err.(*InvalidCodeError); !ok {
+			t.Errorf("Code %s should cause InvalidCodeError, got %T", code, err)
+		}
+	}
+}
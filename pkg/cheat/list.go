@@ -0,0 +1,146 @@
+package cheat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Writer is the memory bus GameShark codes write to.
+type Writer interface {
+	Write(address uint16, value byte)
+}
+
+// List contains cheats of a game. Cheats can be toggled while the emulator is running.
+type List struct {
+	mutex  sync.Mutex
+	cheats []Cheat
+
+	// Enabled Game Genie codes are kept in a separate snapshot,
+	// since they are checked on every ROM read.
+	genie atomic.Value
+}
+
+// NewList creates an empty cheat list.
+func NewList() *List {
+	list := &List{}
+	list.genie.Store([]Cheat{})
+	return list
+}
+
+// LoadFile reads cheats from text file with one code per line, optionally followed by a description.
+// Empty lines and lines starting with # are ignored.
+func LoadFile(path string) (*List, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read reads cheats from text in the same format as LoadFile.
+func Read(r io.Reader) (*List, error) {
+	list := NewList()
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		// Code and description may be separated by any whitespace
+		code := strings.Fields(text)[0]
+		description := strings.TrimSpace(text[len(code):])
+		if err := list.Add(code, description); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Add decodes code and adds it to the list as enabled.
+func (list *List) Add(code string, description string) error {
+	cheat, err := Decode(code)
+	if err != nil {
+		return err
+	}
+	cheat.Description = description
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+	list.cheats = append(list.cheats, *cheat)
+	list.update()
+	return nil
+}
+
+// Cheats returns a copy of cheats in the list.
+func (list *List) Cheats() []Cheat {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+	return append([]Cheat{}, list.cheats...)
+}
+
+// SetEnabled enables or disables cheat at given index. Invalid indices are ignored.
+func (list *List) SetEnabled(index int, enabled bool) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+	if index < 0 || index >= len(list.cheats) {
+		return
+	}
+	list.cheats[index].Enabled = enabled
+	list.update()
+}
+
+// Toggle switches cheat at given index on or off and returns the new state.
+// False is returned for invalid indices.
+func (list *List) Toggle(index int) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+	if index < 0 || index >= len(list.cheats) {
+		return false
+	}
+	list.cheats[index].Enabled = !list.cheats[index].Enabled
+	list.update()
+	return list.cheats[index].Enabled
+}
+
+// PatchROM replaces value read from ROM address if an enabled Game Genie code matches it.
+func (list *List) PatchROM(address uint16, value byte) byte {
+	for _, cheat := range list.genie.Load().([]Cheat) {
+		if cheat.Address == address && (!cheat.HasCompare || cheat.Compare == value) {
+			return cheat.Value
+		}
+	}
+	return value
+}
+
+// Apply writes values of enabled GameShark codes to memory.
+func (list *List) Apply(memory Writer) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+	for _, cheat := range list.cheats {
+		if cheat.Enabled && cheat.Type == GameShark {
+			memory.Write(cheat.Address, cheat.Value)
+		}
+	}
+}
+
+// update refreshes snapshot of enabled Game Genie codes. Mutex must be held by the caller.
+func (list *List) update() {
+	genie := []Cheat{}
+	for _, cheat := range list.cheats {
+		if cheat.Enabled && cheat.Type == GameGenie {
+			genie = append(genie, cheat)
+		}
+	}
+	list.genie.Store(genie)
+}
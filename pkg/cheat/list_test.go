@@ -0,0 +1,91 @@
+package cheat
+
+import (
+	"strings"
+	"testing"
+)
+
+type fakeMemory map[uint16]byte
+
+func (memory fakeMemory) Write(address uint16, value byte) {
+	memory[address] = value
+}
+
+func TestReadCheats(t *testing.T) {
+	text := `
+# Comment
+00A-17B-C49  Infinite lives
+010238CD
+`
+	list, err := Read(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cheats := list.Cheats()
+	if len(cheats) != 2 {
+		t.Fatalf("List should contain 2 cheats, got %d", len(cheats))
+	}
+	if cheats[0].Description != "Infinite lives" || cheats[1].Description != "" {
+		t.Errorf("Descriptions should be read from file, got %q and %q", cheats[0].Description, cheats[1].Description)
+	}
+
+	list, err = Read(strings.NewReader("010FA0C1\tInfinite lives"))
+	if err != nil {
+		t.Fatalf("Code separated from description by tab should be valid, got %v", err)
+	}
+	if cheats := list.Cheats(); len(cheats) != 1 || cheats[0].Description != "Infinite lives" {
+		t.Errorf("Description should be read after tab separator, got %+v", cheats)
+	}
+
+	if _, err := Read(strings.NewReader("00A-17B-C49\nXYZ")); err == nil {
+		t.Error("Invalid code should cause error")
+	} else if !strings.HasPrefix(err.Error(), "line 2") {
+		t.Errorf("Error should contain line number, got %v", err)
+	}
+}
+
+func TestPatchROM(t *testing.T) {
+	list := NewList()
+	list.Add("00A-17B-C49", "")
+	list.Add("3E1-23F", "")
+
+	if value := list.PatchROM(0x4a17, 0xc8); value != 0x00 {
+		t.Errorf("Matching compare value should be patched to %x, got %x", 0x00, value)
+	}
+	if value := list.PatchROM(0x4a17, 0x11); value != 0x11 {
+		t.Errorf("Different compare value should not be patched, got %x", value)
+	}
+	if value := list.PatchROM(0x0123, 0x11); value != 0x3e {
+		t.Errorf("Code without compare should always be patched to %x, got %x", 0x3e, value)
+	}
+
+	if list.Toggle(1) {
+		t.Error("Toggled cheat should be disabled")
+	}
+	if value := list.PatchROM(0x0123, 0x11); value != 0x11 {
+		t.Errorf("Disabled cheat should not patch ROM, got %x", value)
+	}
+	list.SetEnabled(1, true)
+	if value := list.PatchROM(0x0123, 0x11); value != 0x3e {
+		t.Errorf("Enabled cheat should patch ROM, got %x", value)
+	}
+}
+
+func TestApplyGameShark(t *testing.T) {
+	list := NewList()
+	list.Add("010238CD", "")
+	list.Add("00A-17B-C49", "")
+
+	memory := fakeMemory{}
+	list.Apply(memory)
+	if len(memory) != 1 || memory[0xcd38] != 0x02 {
+		t.Errorf("Only GameShark code should write %x to %x, got %v", 0x02, 0xcd38, memory)
+	}
+
+	list.Toggle(0)
+	memory = fakeMemory{}
+	list.Apply(memory)
+	if len(memory) != 0 {
+		t.Errorf("Disabled cheat should not write to memory, got %v", memory)
+	}
+}
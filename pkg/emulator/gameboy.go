@@ -1,10 +1,13 @@
 package emulator
 
 import (
+	"log"
 	"time"
 
 	"github.com/v4t/gomb/pkg/cartridge"
+	"github.com/v4t/gomb/pkg/cheat"
 	"github.com/v4t/gomb/pkg/graphics"
+	"github.com/v4t/gomb/pkg/hardware"
 	"github.com/v4t/gomb/pkg/memory"
 	"github.com/v4t/gomb/pkg/processor"
 )
@@ -15,69 +18,217 @@ const FPS = 100
 // MaxCycles represents clock cycles executed for each frame.
 const MaxCycles = 69905
 
+// SaveInterval defines how often battery backed cartridge RAM is flushed to disk.
+const SaveInterval = 5 * time.Second
+
 // Gameboy emulator.
 type Gameboy struct {
-	CPU     *processor.CPU
-	MMU     *memory.MMU
-	PPU     *graphics.PPU
-	Timer   *Timer
-	Display *graphics.Display
-	Joypad  *graphics.Joypad
+	Cartridge *cartridge.Cartridge
+	CPU       *processor.CPU
+	MMU       *memory.MMU
+	PPU       *graphics.PPU
+	Timer     *Timer
+	Serial    *Serial
+	Display   *graphics.Display
+	Joypad    *graphics.Joypad
+	Tilt      *cartridge.TiltState
+	Cheats    *cheat.List
+
+	model         hardware.Model
+	modelSelected bool
+	bootROM       []byte
+	bootROMs      map[hardware.Model][]byte
 }
 
 // NewGameboy is constructor for gameboy emulator.
-func NewGameboy() *Gameboy {
+func NewGameboy(options ...Option) *Gameboy {
 	display := &graphics.Display{}
 	cpu := processor.NewCPU()
 	ppu := graphics.NewPPU(cpu.MMU, display)
 	joypad := graphics.NewJoypad()
 	timer := &Timer{}
+	serial := &Serial{}
 
-	cpu.MMU.Timer = timer
-	cpu.MMU.Input = joypad
+	cpu.MMU.Map(0xff00, 0xff00, joypad)
+	cpu.MMU.Map(SB, SC, serial)
+	cpu.MMU.Map(DIV, TMC, timer)
+	cpu.MMU.Map(0xff0f, 0xff0f, cpu.Interrupts)
+	cpu.MMU.Map(0xffff, 0xffff, cpu.Interrupts)
 	joypad.Interrupts = cpu.Interrupts
-	cpu.MMU.Interrupts = cpu.Interrupts
 	ppu.Interrupts = cpu.Interrupts
 	timer.Interrupts = cpu.Interrupts
-	return &Gameboy{
+	serial.Interrupts = cpu.Interrupts
+	gb := &Gameboy{
 		CPU:     cpu,
 		PPU:     ppu,
 		MMU:     cpu.MMU,
 		Display: display,
 		Joypad:  joypad,
 		Timer:   timer,
+		Serial:  serial,
+		Tilt:    &cartridge.TiltState{},
+		Cheats:  cheat.NewList(),
+		model:   hardware.DMG,
+	}
+	cpu.Tick = gb.tick
+	for _, option := range options {
+		option(gb)
+	}
+	return gb
+}
+
+// LoadCartridge inserts cartridge to gameboy.
+func (gb *Gameboy) LoadCartridge(cart *cartridge.Cartridge) {
+	gb.Cartridge = cart
+	// Cartridge handles ROM and external RAM
+	gb.MMU.Map(0x0000, 0x7fff, cart)
+	gb.MMU.Map(0xa000, 0xbfff, cart)
+	cart.SetTiltSource(gb.Tilt)
+	cart.SetROMPatcher(gb.Cheats)
+	if len(cart.Override.Palette) == len(gb.Display.Palette) {
+		copy(gb.Display.Palette[:], cart.Override.Palette)
+	}
+
+	if !gb.modelSelected {
+		gb.model = modelForCartridge(cart)
+	}
+	gb.PPU.Model = gb.model
+	gb.MMU.Model = gb.model
+
+	bootROM := gb.bootROM
+	if bootROM == nil {
+		bootROM = gb.bootROMs[gb.model]
 	}
+	if bootROM != nil {
+		gb.MMU.MapBootROM(bootROM)
+	} else {
+		gb.CPU.SkipBoot(gb.model)
+		gb.MMU.SkipBoot(gb.model)
+	}
+}
+
+// Err returns the error which stopped emulation, such as CPU lock up caused by
+// an illegal opcode. Emulator keeps running frames after the error, but CPU doesn't
+// execute any more instructions.
+func (gb *Gameboy) Err() error {
+	return gb.CPU.Err()
+}
+
+// Model returns emulated hardware model. Model is selected when cartridge is loaded,
+// unless it was given as an option.
+func (gb *Gameboy) Model() hardware.Model {
+	return gb.model
+}
+
+// modelForCartridge selects hardware model based on features the game supports.
+func modelForCartridge(cart *cartridge.Cartridge) hardware.Model {
+	if cart.Header.RequiresCGB() {
+		return hardware.CGB
+	} else if cart.Header.SupportsSGB() {
+		return hardware.SGB
+	}
+	return hardware.DMG
 }
 
 // Start gameboy emulator.
 func (gb *Gameboy) Start(cart *cartridge.Cartridge) {
-	gb.MMU.Cartridge = cart
+	gb.LoadCartridge(cart)
 
 	gb.Display.Run(func() {
 		gb.Display.Initialize()
 		t := time.NewTicker(time.Second / FPS)
+		saveTicker := time.NewTicker(SaveInterval)
 		for !gb.Display.Closed() {
 			select {
 			case <-t.C:
 				gb.Update()
+				if cart.FlushPending() {
+					gb.flushSave()
+				}
+			case <-saveTicker.C:
+				gb.flushSave()
 			}
 		}
 	})
 }
 
-// Update gameboy state.
+// flushSave writes battery backed cartridge RAM to disk.
+func (gb *Gameboy) flushSave() {
+	if err := gb.Cartridge.Flush(); err != nil {
+		log.Printf("Error when writing save file: %v", err)
+	}
+}
+
+// Update gameboy state and render the resulting frame.
 func (gb *Gameboy) Update() {
+	locked := gb.CPU.Locked
+	gb.RunFrame()
+	if !locked && gb.CPU.Locked {
+		log.Printf("Emulation stopped: %v", gb.Err())
+	}
+	gb.Display.RenderImage()
+	gb.Display.ProcessInput(gb.Joypad)
+	gb.Tilt.Set(gb.Display.TiltInput())
+	for _, index := range gb.Display.ToggledCheats() {
+		gb.toggleCheat(index)
+	}
+}
+
+// toggleCheat switches cheat at given index on or off.
+func (gb *Gameboy) toggleCheat(index int) {
+	cheats := gb.Cheats.Cheats()
+	if index >= len(cheats) {
+		return
+	}
+	state := "disabled"
+	if gb.Cheats.Toggle(index) {
+		state = "enabled"
+	}
+	log.Printf("Cheat %s %s %s", cheats[index].Code, cheats[index].Description, state)
+}
+
+// RunFrame executes emulation for the duration of a single frame without rendering it.
+func (gb *Gameboy) RunFrame() {
+	// GameShark codes are applied once per frame
+	gb.Cheats.Apply(gb.MMU)
+
 	currentCycles := 0
 	for currentCycles < MaxCycles {
 		cycles := 4
-		if !gb.CPU.Halted {
+		if gb.CPU.Locked {
+			// Locked CPU doesn't execute or respond to interrupts, but PPU keeps running
+			gb.tick(cycles)
+		} else if gb.CPU.Stopped {
+			// Nothing is clocked in stop mode, only pressing a button wakes the CPU up
+			if gb.Joypad.Read(0xff00)&0x0f != 0x0f {
+				gb.CPU.Stopped = false
+			}
+		} else if gb.CPU.Halted {
+			gb.tick(cycles)
+		} else {
+			// CPU ticks other components during the instruction
 			cycles = gb.CPU.Execute()
 		}
+		if !gb.CPU.Stopped && !gb.CPU.Locked {
+			cycles += gb.CPU.Interrupts.Resolve(gb.CPU)
+		}
+		// Frame length is measured in PPU cycles
+		if gb.CPU.DoubleSpeed {
+			cycles /= 2
+		}
 		currentCycles += cycles
-		gb.Timer.Update(cycles)
+	}
+}
+
+// tick advances components running alongside CPU by given amount of clock cycles.
+// Timer and serial port are clocked by CPU, but PPU runs at normal speed when CPU
+// is in double speed mode.
+func (gb *Gameboy) tick(cycles int) {
+	gb.Timer.Update(cycles)
+	gb.Serial.Update(cycles)
+	if gb.CPU.DoubleSpeed {
+		gb.PPU.Execute(cycles / 2)
+	} else {
 		gb.PPU.Execute(cycles)
-		gb.CPU.Interrupts.Resolve(gb.CPU)
 	}
-	gb.Display.RenderImage()
-	gb.Display.ProcessInput(gb.Joypad)
 }
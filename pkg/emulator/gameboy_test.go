@@ -0,0 +1,317 @@
+package emulator
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/v4t/gomb/pkg/cartridge"
+	"github.com/v4t/gomb/pkg/graphics"
+	"github.com/v4t/gomb/pkg/hardware"
+	"github.com/v4t/gomb/pkg/processor"
+)
+
+// newProgramCartridge creates cartridge which starts executing given program at 0x100.
+func newProgramCartridge(t *testing.T, program []byte) *cartridge.Cartridge {
+	rom := make([]byte, 0x8000)
+	copy(rom[0x100:], program)
+	cart, err := cartridge.NewCartridge(rom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cart
+}
+
+// counterProgram adds step to A in a loop and stores the sum to 0xc000.
+func counterProgram(step byte) []byte {
+	return []byte{
+		0x3e, 0x00, // LD A,0x00
+		0x06, step, // LD B,step
+		0x80,             // ADD A,B
+		0xea, 0x00, 0xc0, // LD (0xc000),A
+		0x18, 0xfa, // JR -6
+	}
+}
+
+type gameboyState struct {
+	Registers processor.Registers
+	PC        uint16
+	Counter   byte
+}
+
+func newCounterGameboy(t *testing.T, step byte) *Gameboy {
+	gb := NewGameboy()
+	gb.LoadCartridge(newProgramCartridge(t, counterProgram(step)))
+	return gb
+}
+
+func runFrames(gb *Gameboy, frames int) gameboyState {
+	for i := 0; i < frames; i++ {
+		gb.RunFrame()
+	}
+	return gameboyState{gb.CPU.Registers, gb.CPU.PC, gb.MMU.Read(0xc000)}
+}
+
+func TestParallelGameboys(t *testing.T) {
+	const frames = 5
+	steps := []byte{0x01, 0x03, 0x07, 0x0d}
+
+	// Results from running each instance alone are compared against parallel runs
+	expected := make([]gameboyState, len(steps))
+	for i, step := range steps {
+		expected[i] = runFrames(newCounterGameboy(t, step), frames)
+		if expected[i].Registers.B != step {
+			t.Fatalf("Program with step %x should keep step in B, got %x", step, expected[i].Registers.B)
+		}
+	}
+
+	gameboys := make([]*Gameboy, len(steps))
+	for i, step := range steps {
+		gameboys[i] = newCounterGameboy(t, step)
+	}
+	actual := make([]gameboyState, len(steps))
+	var wg sync.WaitGroup
+	for i, gb := range gameboys {
+		wg.Add(1)
+		go func(i int, gb *Gameboy) {
+			defer wg.Done()
+			actual[i] = runFrames(gb, frames)
+		}(i, gb)
+	}
+	wg.Wait()
+
+	for i, step := range steps {
+		if actual[i] != expected[i] {
+			t.Errorf("Gameboy with step %x should end in state %+v when run in parallel, got %+v", step, expected[i], actual[i])
+		}
+	}
+}
+
+func TestStopMode(t *testing.T) {
+	gb := NewGameboy()
+	gb.LoadCartridge(newProgramCartridge(t, []byte{
+		0x10, 0x00, // STOP
+		0x04,       // INC B
+		0x18, 0xfe, // JR -2
+	}))
+	gb.CPU.Registers.B = 0
+	gb.RunFrame()
+
+	if !gb.CPU.Stopped || gb.CPU.PC != 0x102 || gb.CPU.Registers.B != 0 {
+		t.Fatalf("CPU should stop after skipping byte following STOP, got PC %x B %x", gb.CPU.PC, gb.CPU.Registers.B)
+	}
+	if value := gb.MMU.Read(DIV); value != 0 {
+		t.Errorf("DIV should be reset and not run in stop mode, got %x", value)
+	}
+	scanline := gb.MMU.Read(0xff44)
+	gb.RunFrame()
+	if value := gb.MMU.Read(0xff44); value != scanline {
+		t.Errorf("LCD should not run in stop mode, scanline changed from %d to %d", scanline, value)
+	}
+
+	// Button press only wakes CPU when its line is selected
+	gb.Joypad.KeyPress(graphics.ButtonStart)
+	gb.MMU.Write(0xff00, 0x20)
+	gb.RunFrame()
+	if !gb.CPU.Stopped {
+		t.Fatalf("Unselected button should not wake CPU from stop mode")
+	}
+	gb.MMU.Write(0xff00, 0x10)
+	gb.RunFrame()
+	if gb.CPU.Stopped || gb.CPU.Registers.B != 1 {
+		t.Errorf("Button press should wake CPU from stop mode, got B %x", gb.CPU.Registers.B)
+	}
+}
+
+func TestSpeedSwitch(t *testing.T) {
+	program := []byte{
+		0x3e, 0x01, // LD A,0x01
+		0xe0, 0x4d, // LDH (0x4d),A
+		0x10, 0x00, // STOP
+		0x18, 0xfe, // JR -2
+	}
+
+	gb := NewGameboy(WithModel(hardware.CGB))
+	gb.LoadCartridge(newProgramCartridge(t, program))
+	gb.RunFrame()
+	if gb.CPU.Stopped || !gb.CPU.DoubleSpeed {
+		t.Errorf("Armed STOP should switch CPU to double speed without stopping")
+	}
+	if value := gb.MMU.Read(0xff4d); value != 0xfe {
+		t.Errorf("KEY1 should report double speed with switch disarmed, got %x", value)
+	}
+
+	gb = NewGameboy(WithModel(hardware.DMG))
+	gb.LoadCartridge(newProgramCartridge(t, program))
+	gb.RunFrame()
+	if !gb.CPU.Stopped || gb.CPU.DoubleSpeed {
+		t.Errorf("STOP should not switch speed on DMG")
+	}
+	if value := gb.MMU.Read(0xff4d); value != 0xff {
+		t.Errorf("KEY1 should not be writable on DMG, got %x", value)
+	}
+}
+
+func TestDoubleSpeedFrame(t *testing.T) {
+	program := []byte{
+		0x11, 0x00, 0x00, // LD DE,0x0000
+		0x13,       // INC DE
+		0x18, 0xfd, // JR -3
+	}
+	gb := NewGameboy()
+	gb.LoadCartridge(newProgramCartridge(t, program))
+	gb.RunFrame()
+	normal := gb.CPU.Registers.DE()
+
+	gb = NewGameboy()
+	gb.LoadCartridge(newProgramCartridge(t, program))
+	gb.CPU.DoubleSpeed = true
+	gb.RunFrame()
+	if double := gb.CPU.Registers.DE(); double < normal*19/10 {
+		t.Errorf("CPU should execute twice the instructions per frame in double speed mode, got %d and %d", normal, double)
+	}
+}
+
+func TestIllegalOpcodeLockUp(t *testing.T) {
+	gb := NewGameboy()
+	gb.LoadCartridge(newProgramCartridge(t, []byte{
+		0x3e, 0x01, // LD A,0x01
+		0xe0, 0xff, // LDH (0xff),A
+		0xfb, // EI
+		0xdd, // Illegal
+		0x04, // INC B
+	}))
+	gb.CPU.Registers.B = 0
+	gb.RunFrame()
+
+	var lockUp *processor.IllegalOpcodeError
+	if !errors.As(gb.Err(), &lockUp) {
+		t.Fatalf("Illegal opcode should be reported as error, got %v", gb.Err())
+	}
+	if lockUp.Opcode != 0xdd || lockUp.Address != 0x105 {
+		t.Errorf("Error should report opcode dd at 0105, got %02x at %04x", lockUp.Opcode, lockUp.Address)
+	}
+
+	gb.MMU.Write(0xff0f, 0x00)
+	for frame := 0; frame < 3 && gb.MMU.Read(0xff0f)&0x01 == 0; frame++ {
+		gb.RunFrame()
+	}
+	if gb.CPU.PC != 0x106 || gb.CPU.Registers.B != 0 {
+		t.Errorf("Locked CPU should not execute instructions or interrupts, got PC %x", gb.CPU.PC)
+	}
+	if gb.MMU.Read(0xff0f)&0x01 == 0 {
+		t.Errorf("PPU should keep running and request VBlank while CPU is locked")
+	}
+}
+
+func TestCartridgeRAMSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.sav")
+
+	rom := make([]byte, 0x8000)
+	rom[0x147] = 0x03 // MBC1+RAM+BATTERY
+	rom[0x149] = 0x02 // 8 KB RAM
+	cart, err := cartridge.NewCartridge(rom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cart.LoadSaveFile(path); err != nil {
+		t.Fatal(err)
+	}
+	gb := NewGameboy()
+	gb.LoadCartridge(cart)
+
+	gb.MMU.Write(0x0000, 0x0a)
+	gb.MMU.Write(0xa123, 0x42)
+	if value := gb.MMU.Read(0xa123); value != 0x42 {
+		t.Errorf("External RAM should be readable through MMU, expected %x, got %x", 0x42, value)
+	}
+	if snapshot := cart.RAMSnapshot(); len(snapshot) != 0x2000 || snapshot[0x123] != 0x42 {
+		t.Fatalf("Write through MMU should reach cartridge RAM")
+	}
+	gb.MMU.Write(0x0000, 0x00)
+	if !cart.FlushPending() {
+		t.Fatalf("Disabling RAM through MMU should request flush")
+	}
+	gb.flushSave()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 0x2000 || data[0x123] != 0x42 {
+		t.Errorf("Save file should contain value written through MMU")
+	}
+}
+
+func TestMBC1Banking(t *testing.T) {
+	// 2 MB ROM with bank number at offset 0x2000 of every bank, and 32 KB RAM
+	rom := make([]byte, 0x200000)
+	for bank := 0; bank < 0x80; bank++ {
+		rom[bank*0x4000+0x2000] = byte(bank)
+	}
+	rom[0x147] = 0x03 // MBC1+RAM+BATTERY
+	rom[0x148] = 0x06 // 2 MB ROM
+	rom[0x149] = 0x03 // 32 KB RAM
+	cart, err := cartridge.NewCartridge(rom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gb := NewGameboy()
+	gb.LoadCartridge(cart)
+
+	romTests := []struct {
+		bank1, bank2 byte
+		expected     byte
+	}{
+		{0x00, 0x00, 0x01}, // Bank 0 is mapped to bank 1
+		{0x05, 0x00, 0x05},
+		{0x1f, 0x03, 0x7f},
+		{0x00, 0x03, 0x61}, // Banks 0x20, 0x40 and 0x60 are not accessible from 0x4000-0x7fff
+		{0x20, 0x01, 0x21}, // Only 5 bits of BANK1 are used
+	}
+	for _, test := range romTests {
+		gb.MMU.Write(0x2000, test.bank1)
+		gb.MMU.Write(0x4000, test.bank2)
+		if value := gb.MMU.Read(0x6000); value != test.expected {
+			t.Errorf("BANK1 %x and BANK2 %x should select ROM bank %x, got %x", test.bank1, test.bank2, test.expected, value)
+		}
+	}
+
+	gb.MMU.Write(0x4000, 0x02)
+	if value := gb.MMU.Read(0x2000); value != 0x00 {
+		t.Errorf("Mode 0 should map bank 0 to 0x0000-0x3fff, got bank %x", value)
+	}
+	gb.MMU.Write(0x6000, 0x01)
+	if value := gb.MMU.Read(0x2000); value != 0x40 {
+		t.Errorf("Mode 1 should map bank selected by BANK2 to 0x0000-0x3fff, got bank %x", value)
+	}
+
+	// BANK2 selects RAM bank in mode 1
+	gb.MMU.Write(0x0000, 0x0a)
+	for bank := byte(0); bank < 4; bank++ {
+		gb.MMU.Write(0x4000, bank)
+		gb.MMU.Write(0xa000, 0x10+bank)
+	}
+	snapshot := cart.RAMSnapshot()
+	for bank := 0; bank < 4; bank++ {
+		if snapshot[bank*0x2000] != byte(0x10+bank) {
+			t.Errorf("RAM bank %d should contain %x, got %x", bank, 0x10+bank, snapshot[bank*0x2000])
+		}
+	}
+	gb.MMU.Write(0x6000, 0x00)
+	if value := gb.MMU.Read(0xa000); value != 0x10 {
+		t.Errorf("Mode 0 should always map RAM bank 0, got %x", value)
+	}
+	gb.MMU.Write(0x0000, 0x00)
+	if value := gb.MMU.Read(0xa000); value != 0xff {
+		t.Errorf("Disabled RAM should read 0xff, got %x", value)
+	}
+}
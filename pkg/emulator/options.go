@@ -0,0 +1,80 @@
+package emulator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/v4t/gomb/pkg/hardware"
+)
+
+// Sizes of DMG and CGB boot ROMs.
+const (
+	dmgBootROMSize = 0x100
+	cgbBootROMSize = 0x900
+)
+
+// Option configures gameboy emulator.
+type Option func(gb *Gameboy)
+
+// WithModel selects emulated hardware model. By default model is selected automatically
+// from cartridge header: CGB for games that require it, SGB for games with Super Game Boy
+// features and DMG for the rest. Games that only optionally use Game Boy Color
+// features are run as DMG games.
+func WithModel(model hardware.Model) Option {
+	return func(gb *Gameboy) {
+		gb.model = model
+		gb.modelSelected = true
+	}
+}
+
+// WithBootROM runs given boot ROM when cartridge is loaded, instead of starting
+// from the state boot ROM would leave the hardware in.
+func WithBootROM(rom []byte) Option {
+	return func(gb *Gameboy) {
+		gb.bootROM = rom
+	}
+}
+
+// WithBootROMs runs boot ROM of the selected model when cartridge is loaded.
+// Models without boot ROM start from the state boot ROM would leave the hardware in.
+func WithBootROMs(roms map[hardware.Model][]byte) Option {
+	return func(gb *Gameboy) {
+		gb.bootROMs = roms
+	}
+}
+
+// LoadBootROM reads boot ROM from file and checks that it is the size of DMG or CGB boot ROM.
+func LoadBootROM(path string) ([]byte, error) {
+	rom, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(rom) != dmgBootROMSize && len(rom) != cgbBootROMSize {
+		return nil, fmt.Errorf("invalid boot ROM size %d bytes, expected %d or %d bytes", len(rom), dmgBootROMSize, cgbBootROMSize)
+	}
+	return rom, nil
+}
+
+// BootROMName returns file name of boot ROM for given model, e.g. dmg_boot.bin.
+func BootROMName(model hardware.Model) string {
+	return strings.ToLower(model.String()) + "_boot.bin"
+}
+
+// LoadBootROMs reads boot ROMs of all models from directory. Boot ROMs are named
+// as returned by BootROMName, and models which boot ROM doesn't exist are left out.
+func LoadBootROMs(dir string) (map[hardware.Model][]byte, error) {
+	roms := map[hardware.Model][]byte{}
+	for _, model := range []hardware.Model{hardware.DMG, hardware.MGB, hardware.SGB, hardware.CGB, hardware.AGB} {
+		rom, err := LoadBootROM(filepath.Join(dir, BootROMName(model)))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		roms[model] = rom
+	}
+	return roms, nil
+}
@@ -0,0 +1,143 @@
+package emulator
+
+import (
+	"testing"
+
+	"github.com/v4t/gomb/pkg/cartridge"
+	"github.com/v4t/gomb/pkg/hardware"
+)
+
+// newTestCartridge creates cartridge with given CGB and SGB flags in header.
+func newTestCartridge(t *testing.T, cgbFlag, sgbFlag byte) *cartridge.Cartridge {
+	rom := make([]byte, 0x8000)
+	rom[0x0000] = 0xaa
+	rom[0x0143] = cgbFlag
+	rom[0x0146] = sgbFlag
+	rom[0x014b] = 0x33
+	cart, err := cartridge.NewCartridge(rom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cart
+}
+
+func TestSkipBoot(t *testing.T) {
+	gb := NewGameboy()
+	gb.LoadCartridge(newTestCartridge(t, 0x00, 0x00))
+	if gb.CPU.PC != 0x100 || gb.CPU.SP != 0xfffe {
+		t.Errorf("Execution should start from 0x100 with SP 0xfffe, got PC %x SP %x", gb.CPU.PC, gb.CPU.SP)
+	}
+	if gb.CPU.Registers.A != 0x01 || gb.CPU.Registers.F != 0xb0 {
+		t.Errorf("AF should have DMG value 0x01b0, got %04x", gb.CPU.Registers.AF())
+	}
+	if value := gb.MMU.Read(0xff40); value != 0x91 {
+		t.Errorf("LCDC should be enabled by boot ROM, got %x", value)
+	}
+}
+
+func TestBootROM(t *testing.T) {
+	boot := make([]byte, 0x100)
+	copy(boot, []byte{
+		0x31, 0xfe, 0xff, // LD SP,0xfffe
+		0x3e, 0x01, // LD A,0x01
+		0xc3, 0xfc, 0x00, // JP 0x00fc
+	})
+	copy(boot[0xfc:], []byte{0xe0, 0x50}) // LDH (0x50),A
+
+	gb := NewGameboy(WithBootROM(boot))
+	gb.LoadCartridge(newTestCartridge(t, 0x00, 0x00))
+	if gb.CPU.PC != 0x0000 || gb.CPU.Registers.AF() != 0 {
+		t.Errorf("Execution should start from 0x0000 with cleared registers, got PC %x AF %04x", gb.CPU.PC, gb.CPU.Registers.AF())
+	}
+	if value := gb.MMU.Read(0x0000); value != 0x31 {
+		t.Errorf("Boot ROM should be mapped to 0x0000, got %x", value)
+	}
+	if value := gb.MMU.Read(0xff40); value != 0x00 {
+		t.Errorf("LCDC should be cleared at power on, got %x", value)
+	}
+
+	for i := 0; i < 10 && gb.CPU.PC != 0x100; i++ {
+		gb.CPU.Execute()
+	}
+	if gb.CPU.PC != 0x100 {
+		t.Fatalf("Boot ROM should hand over to cartridge at 0x100, got PC %x", gb.CPU.PC)
+	}
+	if value := gb.MMU.Read(0x0000); value != 0xaa {
+		t.Errorf("Cartridge should be mapped to 0x0000 after boot, got %x", value)
+	}
+	gb.MMU.Write(0xff50, 0x00)
+	if value := gb.MMU.Read(0x0000); value != 0xaa {
+		t.Errorf("Boot ROM should not be mapped again, got %x", value)
+	}
+}
+
+func TestModelSelection(t *testing.T) {
+	tests := []struct {
+		cgbFlag, sgbFlag byte
+		model            hardware.Model
+		a                byte
+	}{
+		{0x00, 0x00, hardware.DMG, 0x01},
+		{0x80, 0x00, hardware.DMG, 0x01},
+		{0xc0, 0x00, hardware.CGB, 0x11},
+		{0x00, 0x03, hardware.SGB, 0x01},
+	}
+	for _, test := range tests {
+		gb := NewGameboy()
+		gb.LoadCartridge(newTestCartridge(t, test.cgbFlag, test.sgbFlag))
+		if gb.Model() != test.model {
+			t.Errorf("Cartridge with CGB flag %x and SGB flag %x should run on %v, got %v", test.cgbFlag, test.sgbFlag, test.model, gb.Model())
+		}
+		if gb.CPU.Registers.A != test.a {
+			t.Errorf("Register A should be %x on %v, got %x", test.a, test.model, gb.CPU.Registers.A)
+		}
+	}
+}
+
+func TestWithModel(t *testing.T) {
+	gb := NewGameboy(WithModel(hardware.MGB))
+	gb.LoadCartridge(newTestCartridge(t, 0xc0, 0x00))
+	if gb.Model() != hardware.MGB {
+		t.Errorf("Selected model should override cartridge header, got %v", gb.Model())
+	}
+	if gb.CPU.Registers.A != 0xff {
+		t.Errorf("Register A should be %x on MGB, got %x", 0xff, gb.CPU.Registers.A)
+	}
+	if value := gb.MMU.Read(0xff4d); value != 0xff {
+		t.Errorf("KEY1 should read %x on MGB, got %x", 0xff, value)
+	}
+
+	gb = NewGameboy(WithModel(hardware.CGB))
+	gb.LoadCartridge(newTestCartridge(t, 0x00, 0x00))
+	if value := gb.MMU.Read(0xff4d); value != 0x7e {
+		t.Errorf("KEY1 should read %x on CGB, got %x", 0x7e, value)
+	}
+}
+
+func TestBootROMForModel(t *testing.T) {
+	dmgBoot := make([]byte, 0x100)
+	dmgBoot[0] = 0x01
+	cgbBoot := make([]byte, 0x900)
+	cgbBoot[0] = 0x02
+	cgbBoot[0x150] = 0xee
+	cgbBoot[0x200] = 0x03
+	roms := map[hardware.Model][]byte{hardware.DMG: dmgBoot, hardware.CGB: cgbBoot}
+
+	gb := NewGameboy(WithBootROMs(roms))
+	gb.LoadCartridge(newTestCartridge(t, 0xc0, 0x00))
+	if value := gb.MMU.Read(0x0000); value != 0x02 {
+		t.Errorf("CGB boot ROM should be mapped, got %x", value)
+	}
+	if value := gb.MMU.Read(0x0150); value != 0x00 {
+		t.Errorf("Cartridge header should be visible at 0x0150, got %x", value)
+	}
+	if value := gb.MMU.Read(0x0200); value != 0x03 {
+		t.Errorf("CGB boot ROM should be mapped to 0x0200, got %x", value)
+	}
+
+	gb = NewGameboy(WithBootROMs(roms), WithModel(hardware.SGB))
+	gb.LoadCartridge(newTestCartridge(t, 0x00, 0x00))
+	if gb.CPU.PC != 0x100 {
+		t.Errorf("Boot should be skipped for model without boot ROM, got PC %x", gb.CPU.PC)
+	}
+}
@@ -0,0 +1,77 @@
+package emulator
+
+import (
+	"io"
+
+	"github.com/v4t/gomb/pkg/processor"
+)
+
+// Addresses for serial port registers.
+const (
+	SB uint16 = 0xff01
+	SC uint16 = 0xff02
+)
+
+// serialTransferCycles is the duration of 8 bit transfer with internal clock of 8192 Hz.
+const serialTransferCycles = 8 * 512
+
+// Serial handles serial port registers. Link cable is not connected, so transfers
+// started with internal clock complete by shifting in 0xff from the unconnected line.
+type Serial struct {
+	Interrupts *processor.Interrupts
+	// Output receives bytes sent through serial port, if set
+	Output io.Writer
+
+	counter      int
+	transferring bool
+
+	// Registers
+	sb byte // Serial transfer data
+	sc byte // Serial transfer control
+}
+
+// Read value from one of the serial registers.
+func (serial *Serial) Read(address uint16) byte {
+	switch address {
+	case SB:
+		return serial.sb
+	case SC:
+		return serial.sc | 0x7e // Unused bits always return 1
+	default:
+		panic("Attempted to read serial registers with invalid address.")
+	}
+}
+
+// Write value to one of the serial registers.
+func (serial *Serial) Write(address uint16, value byte) {
+	switch address {
+	case SB:
+		serial.sb = value
+	case SC:
+		serial.sc = value
+		// Transfers with external clock never complete, as there is nothing to provide the clock
+		if value&0x81 == 0x81 {
+			serial.transferring = true
+			serial.counter = 0
+			if serial.Output != nil {
+				serial.Output.Write([]byte{serial.sb})
+			}
+		}
+	default:
+		panic("Attempted to write to serial registers with invalid address.")
+	}
+}
+
+// Update serial transfer state.
+func (serial *Serial) Update(cycles int) {
+	if !serial.transferring {
+		return
+	}
+	serial.counter += cycles
+	if serial.counter >= serialTransferCycles {
+		serial.transferring = false
+		serial.sb = 0xff
+		serial.sc &^= 0x80
+		serial.Interrupts.SetInterrupt(processor.SerialInterrupt)
+	}
+}
@@ -0,0 +1,48 @@
+package emulator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/v4t/gomb/pkg/processor"
+)
+
+func TestSerialTransfer(t *testing.T) {
+	var out bytes.Buffer
+	interrupts := processor.NewInterrupts()
+	interrupts.IF = 0
+	serial := &Serial{Interrupts: interrupts, Output: &out}
+
+	serial.Write(SB, 'A')
+	serial.Write(SC, 0x81)
+	if out.String() != "A" {
+		t.Errorf("Transferred byte should be written to output, got %q", out.String())
+	}
+
+	serial.Update(serialTransferCycles - 4)
+	if serial.Read(SC)&0x80 == 0 || interrupts.IF != 0 {
+		t.Fatalf("Transfer should not complete before 8 bits are shifted")
+	}
+	serial.Update(4)
+	if value := serial.Read(SC); value&0x80 != 0 {
+		t.Errorf("Transfer flag should be cleared when transfer completes, got SC %x", value)
+	}
+	if value := serial.Read(SB); value != 0xff {
+		t.Errorf("Disconnected link should shift in 0xff, got %x", value)
+	}
+	if interrupts.IF != 1<<processor.SerialInterrupt {
+		t.Errorf("Serial interrupt should be requested, got IF %x", interrupts.IF)
+	}
+}
+
+func TestSerialExternalClock(t *testing.T) {
+	interrupts := processor.NewInterrupts()
+	interrupts.IF = 0
+	serial := &Serial{Interrupts: interrupts}
+
+	serial.Write(SC, 0x80)
+	serial.Update(serialTransferCycles * 2)
+	if serial.Read(SC)&0x80 == 0 || interrupts.IF != 0 {
+		t.Errorf("Transfer with external clock should not complete without link partner")
+	}
+}
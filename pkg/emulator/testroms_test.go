@@ -0,0 +1,144 @@
+package emulator
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/v4t/gomb/pkg/cartridge"
+)
+
+// Test ROMs are not distributed with the repository. GOMB_TEST_ROMS should point to a directory
+// containing mooneye-test-suite build in mooneye/ and blargg's test ROMs in blargg/.
+const testROMsEnv = "GOMB_TEST_ROMS"
+
+// maxTestFrames limits how long a single test ROM is run before it is considered stuck.
+const maxTestFrames = 60 * 60
+
+// loadTestROM creates gameboy with given test ROM inserted, or skips the test if ROM is not available.
+func loadTestROM(t *testing.T, name string) *Gameboy {
+	dir := os.Getenv(testROMsEnv)
+	if dir == "" {
+		t.Skipf("%s is not set", testROMsEnv)
+	}
+	rom, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if os.IsNotExist(err) {
+		t.Skipf("Test ROM %s not found", name)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	cart, err := cartridge.NewCartridge(rom)
+	if err != nil {
+		t.Fatalf("Loading test ROM %s failed: %v", name, err)
+	}
+	gb := NewGameboy()
+	gb.LoadCartridge(cart)
+	return gb
+}
+
+// runMooneyeTest runs mooneye test ROM until it reports the result in registers.
+// Passing tests load Fibonacci numbers to registers, and failing tests set them to 0x42.
+func runMooneyeTest(t *testing.T, name string) {
+	gb := loadTestROM(t, name)
+	regs := &gb.CPU.Registers
+	for frame := 0; frame < maxTestFrames; frame++ {
+		gb.RunFrame()
+		if err := gb.Err(); err != nil {
+			t.Fatalf("Test ROM %s failed: %v", name, err)
+		}
+		if regs.B == 3 && regs.C == 5 && regs.D == 8 && regs.E == 13 && regs.H == 21 && regs.L == 34 {
+			return
+		}
+		if regs.B == 0x42 && regs.C == 0x42 && regs.D == 0x42 && regs.E == 0x42 && regs.H == 0x42 && regs.L == 0x42 {
+			t.Fatalf("Test ROM %s failed", name)
+		}
+	}
+	t.Fatalf("Test ROM %s timed out", name)
+}
+
+// runBlarggTest runs blargg's test ROM until it prints the result to serial port.
+func runBlarggTest(t *testing.T, name string) {
+	gb := loadTestROM(t, name)
+	var out bytes.Buffer
+	gb.Serial.Output = &out
+	for frame := 0; frame < maxTestFrames; frame++ {
+		gb.RunFrame()
+		if err := gb.Err(); err != nil {
+			t.Fatalf("Test ROM %s failed: %v\n%s", name, err, out.String())
+		}
+		if strings.Contains(out.String(), "Passed") {
+			return
+		}
+		if strings.Contains(out.String(), "Failed") {
+			t.Fatalf("Test ROM %s failed:\n%s", name, out.String())
+		}
+	}
+	t.Fatalf("Test ROM %s timed out:\n%s", name, out.String())
+}
+
+func runMooneyeTests(t *testing.T, dir string, roms []string) {
+	for _, rom := range roms {
+		path := filepath.Join("mooneye", dir, rom)
+		t.Run(rom, func(t *testing.T) {
+			runMooneyeTest(t, path)
+		})
+	}
+}
+
+func TestMooneyeMBC1(t *testing.T) {
+	runMooneyeTests(t, "emulator-only/mbc1", []string{
+		"bits_bank1.gb",
+		"bits_bank2.gb",
+		"bits_mode.gb",
+		"bits_ramg.gb",
+		"multicart_rom_8Mb.gb",
+		"ram_64kb.gb",
+		"ram_256kb.gb",
+		"rom_512kb.gb",
+		"rom_1Mb.gb",
+		"rom_2Mb.gb",
+		"rom_4Mb.gb",
+		"rom_8Mb.gb",
+		"rom_16Mb.gb",
+	})
+}
+
+func TestBlarggInstrTiming(t *testing.T) {
+	runBlarggTest(t, filepath.Join("blargg", "instr_timing", "instr_timing.gb"))
+}
+
+func TestBlarggMemTiming(t *testing.T) {
+	runBlarggTest(t, filepath.Join("blargg", "mem_timing", "mem_timing.gb"))
+}
+
+func TestMooneyeInterrupts(t *testing.T) {
+	runMooneyeTests(t, "acceptance", []string{
+		"interrupts/ie_push.gb",
+		"halt_ime0_ei.gb",
+		"halt_ime0_nointr_timing.gb",
+		"halt_ime1_timing.gb",
+		"halt_ime1_timing2-GS.gb",
+		"di_timing-GS.gb",
+	})
+}
+
+func TestMooneyeTimer(t *testing.T) {
+	runMooneyeTests(t, "acceptance/timer", []string{
+		"div_write.gb",
+		"rapid_toggle.gb",
+		"tim00.gb",
+		"tim00_div_trigger.gb",
+		"tim01.gb",
+		"tim01_div_trigger.gb",
+		"tim10.gb",
+		"tim10_div_trigger.gb",
+		"tim11.gb",
+		"tim11_div_trigger.gb",
+		"tima_reload.gb",
+		"tima_write_reloading.gb",
+		"tma_write_reloading.gb",
+	})
+}
@@ -13,16 +13,30 @@ const (
 	TMC  uint16 = 0xff07
 )
 
+// Bits of system counter which clock TIMA at the frequencies selected in TMC register.
+var timerBits = [4]uint16{
+	1 << 9, // 4096Hz
+	1 << 3, // 262144Hz
+	1 << 5, // 65536Hz
+	1 << 7, // 16384Hz
+}
+
 // Timer handles timer register updates and memory operations.
+// All timer registers are driven by a 16-bit system counter incremented on every clock cycle.
+// DIV is the upper byte of the counter, and TIMA is incremented on the falling edge of
+// the counter bit selected in TMC, which also makes writes to DIV and TMC increment TIMA.
 type Timer struct {
 	Interrupts *processor.Interrupts
 
-	// Internal counters
-	counter int
-	divider int
+	// Internal system counter
+	counter uint16
+
+	// TIMA overflowed during the previous machine cycle and is reloaded from TMA in the next one
+	overflowed bool
+	// TIMA is being reloaded from TMA during current machine cycle
+	reloading bool
 
 	// Registers
-	div  byte // Divider register
 	tima byte // Timer counter
 	tma  byte // Timer modulo
 	tmc  byte // Timer control
@@ -32,13 +46,13 @@ type Timer struct {
 func (timer *Timer) Read(address uint16) byte {
 	switch address {
 	case DIV:
-		return timer.div
+		return byte(timer.counter >> 8)
 	case TIMA:
 		return timer.tima
 	case TMA:
 		return timer.tma
 	case TMC:
-		return timer.tmc
+		return timer.tmc | 0xf8 // Unused bits always return 1
 	default:
 		panic("Attempted to read timer registers with invalid address.")
 	}
@@ -48,13 +62,24 @@ func (timer *Timer) Read(address uint16) byte {
 func (timer *Timer) Write(address uint16, value byte) {
 	switch address {
 	case DIV:
-		timer.div = 0
+		signal := timer.signal()
+		timer.counter = 0
+		timer.detectFallingEdge(signal)
 	case TIMA:
-		timer.tima = value
+		// TIMA can't be written during reload, and writing it after overflow cancels the reload
+		if !timer.reloading {
+			timer.tima = value
+			timer.overflowed = false
+		}
 	case TMA:
 		timer.tma = value
+		if timer.reloading {
+			timer.tima = value
+		}
 	case TMC:
+		signal := timer.signal()
 		timer.tmc = value
+		timer.detectFallingEdge(signal)
 	default:
 		panic("Attempted to write to timer registers with invalid address.")
 	}
@@ -62,47 +87,45 @@ func (timer *Timer) Write(address uint16, value byte) {
 
 // Update timer registers.
 func (timer *Timer) Update(cycles int) {
-	timer.updateDividerRegister(cycles)
-	if timer.Enabled() {
-		timer.counter += cycles
-		freq := timer.getClockFrequency()
-		for timer.counter >= freq {
-			timer.counter -= freq
-			if timer.tima == 0xff {
-				timer.tima = timer.tma
-				timer.Interrupts.SetInterrupt(processor.TimerInterrupt)
-			} else {
-				timer.tima++
-			}
-		}
+	for ; cycles > 0; cycles -= 4 {
+		timer.step()
 	}
 }
 
-// Enabled checks if timer is runnign from TMC register.
+// Enabled checks if timer is running from TMC register.
 func (timer *Timer) Enabled() bool {
 	return utils.TestBit(timer.tmc, 2)
 }
 
-// getClockFrequency returns current frequency based on TMC register.
-func (timer *Timer) getClockFrequency() int {
-	switch timer.tmc & 0x3 {
-	case 0:
-		return 1024 // 4096Hz
-	case 1:
-		return 16 // 262144Hz
-	case 2:
-		return 64 // 65536Hz
-	default:
-		return 256 // 16384Hz
+// step advances timer by one machine cycle.
+func (timer *Timer) step() {
+	timer.reloading = false
+	if timer.overflowed {
+		timer.overflowed = false
+		timer.reloading = true
+		timer.tima = timer.tma
+		timer.Interrupts.SetInterrupt(processor.TimerInterrupt)
 	}
+	signal := timer.signal()
+	timer.counter += 4
+	timer.detectFallingEdge(signal)
 }
 
-// updateDividerRegister performs the periodical divider register update.
-// DIV register does a periodical increment at 16482Hz frequency which is equivalent to256 CPU clock cycles.
-func (timer *Timer) updateDividerRegister(cycles int) {
-	timer.divider += cycles
-	if timer.divider >= 255 {
-		timer.divider -= 255
-		timer.div++
+// signal returns the state of counter bit selected in TMC, combined with timer enable bit.
+func (timer *Timer) signal() bool {
+	return timer.Enabled() && timer.counter&timerBits[timer.tmc&0x3] != 0
+}
+
+// detectFallingEdge increments TIMA if timer signal has changed from high to low.
+func (timer *Timer) detectFallingEdge(previous bool) {
+	if !previous || timer.signal() {
+		return
+	}
+	if timer.tima == 0xff {
+		// TIMA reads zero for one machine cycle before it is reloaded
+		timer.tima = 0
+		timer.overflowed = true
+	} else {
+		timer.tima++
 	}
 }
@@ -0,0 +1,141 @@
+package emulator
+
+import (
+	"testing"
+
+	"github.com/v4t/gomb/pkg/processor"
+)
+
+func newTestTimer() *Timer {
+	interrupts := processor.NewInterrupts()
+	interrupts.IF = 0
+	return &Timer{Interrupts: interrupts}
+}
+
+func TestDividerRegister(t *testing.T) {
+	timer := newTestTimer()
+	timer.Update(252)
+	if value := timer.Read(DIV); value != 0 {
+		t.Errorf("DIV should not increment before 256 cycles, got %x", value)
+	}
+	timer.Update(4)
+	if value := timer.Read(DIV); value != 1 {
+		t.Errorf("DIV should increment every 256 cycles, got %x", value)
+	}
+	timer.Update(256 * 10)
+	if value := timer.Read(DIV); value != 11 {
+		t.Errorf("DIV should increment every 256 cycles, got %x", value)
+	}
+	timer.Write(DIV, 0x42)
+	if value := timer.Read(DIV); value != 0 {
+		t.Errorf("Writing DIV should reset it, got %x", value)
+	}
+}
+
+func TestTimerFrequencies(t *testing.T) {
+	tests := []struct {
+		tmc    byte
+		period int
+	}{
+		{0x04, 1024},
+		{0x05, 16},
+		{0x06, 64},
+		{0x07, 256},
+	}
+	for _, test := range tests {
+		timer := newTestTimer()
+		timer.Write(TMC, test.tmc)
+		timer.Update(test.period * 10)
+		if value := timer.Read(TIMA); value != 10 {
+			t.Errorf("TIMA should increment every %d cycles with TMC %x, got %d increments", test.period, test.tmc, value)
+		}
+	}
+
+	timer := newTestTimer()
+	timer.Write(TMC, 0x01)
+	timer.Update(1024)
+	if value := timer.Read(TIMA); value != 0 {
+		t.Errorf("Disabled timer should not increment TIMA, got %x", value)
+	}
+}
+
+func TestTimerFallingEdgeOnWrite(t *testing.T) {
+	// Bit 3 of system counter is high after 8 cycles
+	timer := newTestTimer()
+	timer.Write(TMC, 0x05)
+	timer.Update(8)
+	timer.Write(DIV, 0)
+	if value := timer.Read(TIMA); value != 1 {
+		t.Errorf("Resetting DIV while selected bit is high should increment TIMA, got %x", value)
+	}
+
+	timer = newTestTimer()
+	timer.Write(TMC, 0x05)
+	timer.Update(8)
+	timer.Write(TMC, 0x01)
+	if value := timer.Read(TIMA); value != 1 {
+		t.Errorf("Disabling timer while selected bit is high should increment TIMA, got %x", value)
+	}
+
+	timer = newTestTimer()
+	timer.Write(TMC, 0x05)
+	timer.Update(8)
+	timer.Write(TMC, 0x06)
+	if value := timer.Read(TIMA); value != 1 {
+		t.Errorf("Selecting low bit while selected bit is high should increment TIMA, got %x", value)
+	}
+}
+
+// newOverflowingTimer creates timer which overflows TIMA during the next machine cycle.
+func newOverflowingTimer() *Timer {
+	timer := newTestTimer()
+	timer.Write(TMC, 0x05)
+	timer.Write(TMA, 0x42)
+	timer.Update(12)
+	timer.Write(TIMA, 0xff)
+	return timer
+}
+
+func TestTimerOverflow(t *testing.T) {
+	timer := newOverflowingTimer()
+	timer.Update(4)
+	if value := timer.Read(TIMA); value != 0 || timer.Interrupts.IF != 0 {
+		t.Errorf("TIMA should read zero without interrupt during the cycle after overflow, got %x", value)
+	}
+	timer.Update(4)
+	if value := timer.Read(TIMA); value != 0x42 {
+		t.Errorf("TIMA should be reloaded from TMA, got %x", value)
+	}
+	if timer.Interrupts.IF != 1<<processor.TimerInterrupt {
+		t.Errorf("Timer interrupt should be requested on reload, got IF %x", timer.Interrupts.IF)
+	}
+}
+
+func TestTimerWriteDuringOverflow(t *testing.T) {
+	timer := newOverflowingTimer()
+	timer.Update(4)
+	timer.Write(TIMA, 0x10)
+	timer.Update(4)
+	if value := timer.Read(TIMA); value != 0x10 || timer.Interrupts.IF != 0 {
+		t.Errorf("Writing TIMA after overflow should cancel reload and interrupt, got TIMA %x IF %x", value, timer.Interrupts.IF)
+	}
+
+	timer = newOverflowingTimer()
+	timer.Update(8)
+	timer.Write(TIMA, 0x10)
+	if value := timer.Read(TIMA); value != 0x42 {
+		t.Errorf("Writing TIMA during reload should be ignored, got %x", value)
+	}
+
+	timer = newOverflowingTimer()
+	timer.Update(8)
+	timer.Write(TMA, 0x24)
+	if value := timer.Read(TIMA); value != 0x24 {
+		t.Errorf("Writing TMA during reload should also load TIMA, got %x", value)
+	}
+	timer.Update(4)
+	timer.Write(TMA, 0x33)
+	if value := timer.Read(TIMA); value != 0x24 {
+		t.Errorf("Writing TMA after reload should not affect TIMA, got %x", value)
+	}
+}
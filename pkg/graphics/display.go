@@ -23,6 +23,12 @@ const (
 	ScreenHeight = 144
 )
 
+// Keys for toggling cheats by their position in cheat list.
+var cheatKeys = []pixelgl.Button{
+	pixelgl.KeyF1, pixelgl.KeyF2, pixelgl.KeyF3, pixelgl.KeyF4, pixelgl.KeyF5,
+	pixelgl.KeyF6, pixelgl.KeyF7, pixelgl.KeyF8, pixelgl.KeyF9,
+}
+
 // Keyboard to joypad button mappings.
 var keyMap = map[pixelgl.Button]JoypadButton{
 	pixelgl.KeyZ:         ButtonA,
@@ -58,7 +64,9 @@ func (display *Display) Initialize() {
 	}
 
 	display.enabled = true
-	display.Palette = DefaultPalette
+	if display.Palette == ([4]color.RGBA{}) {
+		display.Palette = DefaultPalette
+	}
 
 	win.Clear(colornames.Black)
 	display.window = win
@@ -160,3 +168,31 @@ func (display *Display) ProcessInput(joypad *Joypad) {
 		}
 	}
 }
+
+// TiltInput returns accelerometer tilt controlled with I, J, K and L keys.
+func (display *Display) TiltInput() (x, y float64) {
+	if display.window.Pressed(pixelgl.KeyJ) {
+		x--
+	}
+	if display.window.Pressed(pixelgl.KeyL) {
+		x++
+	}
+	if display.window.Pressed(pixelgl.KeyI) {
+		y--
+	}
+	if display.window.Pressed(pixelgl.KeyK) {
+		y++
+	}
+	return x, y
+}
+
+// ToggledCheats returns indices of cheats toggled with F1-F9 keys.
+func (display *Display) ToggledCheats() []int {
+	var toggled []int
+	for i, key := range cheatKeys {
+		if display.window.JustPressed(key) {
+			toggled = append(toggled, i)
+		}
+	}
+	return toggled
+}
@@ -1,6 +1,9 @@
 package graphics
 
 import (
+	"sort"
+
+	"github.com/v4t/gomb/pkg/hardware"
 	"github.com/v4t/gomb/pkg/memory"
 	"github.com/v4t/gomb/pkg/processor"
 	"github.com/v4t/gomb/pkg/utils"
@@ -17,8 +20,14 @@ const (
 	PixelTransfer = 3
 )
 
+// Maximum number of sprites drawn on a single scanline.
+const maxSpritesPerLine = 10
+
 // PPU represents pixel processing unit.
 type PPU struct {
+	// Model selects model specific behaviour such as sprite priority
+	Model hardware.Model
+
 	scanlineCounter int
 	state           PPUState
 	registers       *PPURegisters
@@ -222,10 +231,33 @@ func (ppu *PPU) renderSprites() {
 		ysize = 16
 	}
 
-	for sprite := 0; sprite < 40; sprite++ {
+	// Select up to 10 sprites on current line in OAM order
+	var sprites []int
+	for sprite := 0; sprite < 40 && len(sprites) < maxSpritesPerLine; sprite++ {
+		yPos := int(ppu.MMU.Read(0xfe00+uint16(sprite*4))) - 16
+		if scanline >= yPos && scanline < yPos+ysize {
+			sprites = append(sprites, sprite)
+		}
+	}
+
+	// Sprites are drawn from lowest to highest priority, so that higher priority sprites end up on top.
+	// On CGB sprite earlier in OAM has higher priority, while on other models sprite
+	// with smaller X coordinate has higher priority.
+	sort.SliceStable(sprites, func(i, j int) bool {
+		if !ppu.Model.IsCGB() {
+			xi := ppu.MMU.Read(0xfe00 + uint16(sprites[i]*4) + 1)
+			xj := ppu.MMU.Read(0xfe00 + uint16(sprites[j]*4) + 1)
+			if xi != xj {
+				return xi > xj
+			}
+		}
+		return sprites[i] > sprites[j]
+	})
+
+	for _, sprite := range sprites {
 		// Get sprite information
 		index := uint16(sprite * 4)
-		yPos := int(ppu.MMU.Read(0xfe00+index) - 16)
+		yPos := int(ppu.MMU.Read(0xfe00+index)) - 16
 		xPos := int(ppu.MMU.Read(0xfe00+index+1) - 8)
 		tileLocation := uint16(ppu.MMU.Read(0xfe00 + index + 2))
 		attributes := ppu.MMU.Read(0xfe00 + index + 3)
@@ -233,10 +265,6 @@ func (ppu *PPU) renderSprites() {
 		yFlip := utils.TestBit(attributes, 6)
 		xFlip := utils.TestBit(attributes, 5)
 
-		if scanline < yPos || scanline >= (yPos+ysize) {
-			continue
-		}
-
 		// Set sprite line
 		line := scanline - yPos
 		if yFlip {
@@ -19,8 +19,6 @@ type PPURegisters struct {
 
 // NewPPURegisters is constructor for PPURegisters.
 func NewPPURegisters(mmu *memory.MMU) *PPURegisters {
-	mmu.Memory[0xff40] = 0x91
-	mmu.Memory[0xff41] = 0x85
 	return &PPURegisters{
 		LcdControl:  PPURegister{mmu: mmu, address: 0xff40},
 		LcdStatus:   PPURegister{mmu: mmu, address: 0xff41},
@@ -0,0 +1,74 @@
+// Package hardware defines Game Boy hardware models and the differences between them.
+package hardware
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Model represents a Game Boy hardware revision.
+type Model int
+
+// Supported hardware models.
+const (
+	// DMG is the original Game Boy.
+	DMG Model = iota
+	// MGB is Game Boy Pocket.
+	MGB
+	// SGB is Super Game Boy.
+	SGB
+	// CGB is Game Boy Color.
+	CGB
+	// AGB is Game Boy Advance running Game Boy games.
+	AGB
+)
+
+// String returns the name of the model.
+func (model Model) String() string {
+	switch model {
+	case MGB:
+		return "MGB"
+	case SGB:
+		return "SGB"
+	case CGB:
+		return "CGB"
+	case AGB:
+		return "AGB"
+	}
+	return "DMG"
+}
+
+// ParseModel returns model by its name, e.g. "dmg" or "CGB".
+func ParseModel(name string) (Model, error) {
+	for _, model := range []Model{DMG, MGB, SGB, CGB, AGB} {
+		if strings.EqualFold(name, model.String()) {
+			return model, nil
+		}
+	}
+	return DMG, fmt.Errorf("unknown hardware model %q, expected one of DMG, MGB, SGB, CGB or AGB", name)
+}
+
+// IsCGB checks if model has Game Boy Color hardware, which is also the case with AGB.
+func (model Model) IsCGB() bool {
+	return model == CGB || model == AGB
+}
+
+// Registers contains CPU register values left by boot ROM.
+type Registers struct {
+	A, F, B, C, D, E, H, L byte
+}
+
+// PostBootRegisters returns CPU register values left by boot ROM of the model.
+func (model Model) PostBootRegisters() Registers {
+	switch model {
+	case MGB:
+		return Registers{A: 0xff, F: 0xb0, B: 0x00, C: 0x13, D: 0x00, E: 0xd8, H: 0x01, L: 0x4d}
+	case SGB:
+		return Registers{A: 0x01, F: 0x00, B: 0x00, C: 0x14, D: 0x00, E: 0x00, H: 0xc0, L: 0x60}
+	case CGB:
+		return Registers{A: 0x11, F: 0x80, B: 0x00, C: 0x00, D: 0xff, E: 0x56, H: 0x00, L: 0x0d}
+	case AGB:
+		return Registers{A: 0x11, F: 0x00, B: 0x01, C: 0x00, D: 0xff, E: 0x56, H: 0x00, L: 0x0d}
+	}
+	return Registers{A: 0x01, F: 0xb0, B: 0x00, C: 0x13, D: 0x00, E: 0xd8, H: 0x01, L: 0x4d}
+}
@@ -0,0 +1,112 @@
+// Package loader reads ROM images from plain files and from zip and gzip archives.
+package loader
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// Signatures used for detecting archive format.
+var (
+	zipMagic  = []byte("PK\x03\x04")
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// ROM file extensions searched from zip archives.
+var romExtensions = []string{".gb", ".gbc"}
+
+// NoROMError is returned when zip archive doesn't contain any ROM files.
+type NoROMError struct {
+	Archive string
+}
+
+func (err *NoROMError) Error() string {
+	return fmt.Sprintf("%s doesn't contain .gb or .gbc files", err.Archive)
+}
+
+// EntryNotFoundError is returned when requested entry doesn't exist in zip archive.
+type EntryNotFoundError struct {
+	Archive string
+	Entry   string
+}
+
+func (err *EntryNotFoundError) Error() string {
+	return fmt.Sprintf("%s doesn't contain %s", err.Archive, err.Entry)
+}
+
+// Load reads ROM from given file. Zip and gzip archives are detected from file contents
+// and decompressed. From zip archives the named entry is read, or the first .gb or .gbc
+// file if entry is empty. Entry is ignored for other files.
+func Load(path string, entry string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, zipMagic) {
+		return readZip(path, data, entry)
+	} else if bytes.HasPrefix(data, gzipMagic) {
+		return readGzip(data)
+	}
+	return data, nil
+}
+
+// ROMPath returns path of the ROM file with archive extension removed,
+// so that save and patch files of an archived ROM are named after the archive.
+// E.g. both tetris.zip and tetris.gb.gz result in a name that gives tetris.sav.
+func ROMPath(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".gz" && ext != ".zip" {
+		return path
+	}
+	path = strings.TrimSuffix(path, filepath.Ext(path))
+	if !isROMFile(path) {
+		path += ".gb"
+	}
+	return path
+}
+
+func readZip(path string, data []byte, entry string) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range archive.File {
+		if entry == "" && !isROMFile(file.Name) || entry != "" && file.Name != entry {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return ioutil.ReadAll(rc)
+	}
+	if entry != "" {
+		return nil, &EntryNotFoundError{Archive: path, Entry: entry}
+	}
+	return nil, &NoROMError{Archive: path}
+}
+
+func readGzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+func isROMFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, romExt := range romExtensions {
+		if ext == romExt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package loader
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "gomb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func writeZip(t *testing.T, path string, files map[string][]byte, order []string) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range order {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write(files[name])
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadPlainFile(t *testing.T) {
+	path := filepath.Join(createTempDir(t), "game.gb")
+	ioutil.WriteFile(path, []byte{1, 2, 3}, 0644)
+	data, err := Load(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte{1, 2, 3}) {
+		t.Errorf("ROM should be read as is, got %x", data)
+	}
+}
+
+func TestLoadZip(t *testing.T) {
+	path := filepath.Join(createTempDir(t), "games.zip")
+	files := map[string][]byte{
+		"readme.txt": []byte("hello"),
+		"first.GB":   {1},
+		"second.gbc": {2},
+	}
+	writeZip(t, path, files, []string{"readme.txt", "first.GB", "second.gbc"})
+
+	if data, err := Load(path, ""); err != nil || !bytes.Equal(data, []byte{1}) {
+		t.Errorf("First ROM file should be loaded, got %x, %v", data, err)
+	}
+	if data, err := Load(path, "second.gbc"); err != nil || !bytes.Equal(data, []byte{2}) {
+		t.Errorf("Named entry should be loaded, got %x, %v", data, err)
+	}
+	if _, err := Load(path, "missing.gb"); err == nil {
+		t.Error("Missing entry should cause error")
+	} else if _, ok := err.(*EntryNotFoundError); !ok {
+		t.Errorf("Error should be EntryNotFoundError, got %T", err)
+	}
+}
+
+func TestLoadZipWithoutROM(t *testing.T) {
+	path := filepath.Join(createTempDir(t), "empty.zip")
+	writeZip(t, path, map[string][]byte{"readme.txt": nil}, []string{"readme.txt"})
+	if _, err := Load(path, ""); err == nil {
+		t.Error("Archive without ROM should cause error")
+	} else if _, ok := err.(*NoROMError); !ok {
+		t.Errorf("Error should be NoROMError, got %T", err)
+	}
+}
+
+func TestLoadGzip(t *testing.T) {
+	path := filepath.Join(createTempDir(t), "game.gb.gz")
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte{4, 5, 6})
+	w.Close()
+	ioutil.WriteFile(path, buf.Bytes(), 0644)
+
+	data, err := Load(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte{4, 5, 6}) {
+		t.Errorf("Decompressed ROM should be %x, got %x", []byte{4, 5, 6}, data)
+	}
+}
+
+func TestROMPath(t *testing.T) {
+	paths := map[string]string{
+		"roms/tetris.gb":     "roms/tetris.gb",
+		"roms/tetris.zip":    "roms/tetris.gb",
+		"roms/tetris.gbc.gz": "roms/tetris.gbc",
+		"roms/v1.0.ZIP":      "roms/v1.0.gb",
+	}
+	for path, expected := range paths {
+		if romPath := ROMPath(path); romPath != expected {
+			t.Errorf("ROM path of %s should be %s, got %s", path, expected, romPath)
+		}
+	}
+}
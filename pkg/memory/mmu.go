@@ -1,7 +1,10 @@
 package memory
 
 import (
+	"fmt"
 	"math"
+
+	"github.com/v4t/gomb/pkg/hardware"
 )
 
 // MemoryRegion represents a specific memory region / block managed by MMU.
@@ -10,18 +13,64 @@ type MemoryRegion interface {
 	Write(address uint16, value byte)
 }
 
-// MMU manages RAM, ROM and cartridge data.
+// MMU manages RAM, ROM and cartridge data. Devices such as cartridge and timer register
+// themselves as handlers for their address ranges, and addresses without a handler
+// are backed by plain memory.
 type MMU struct {
-	Memory     []byte
-	Cartridge  MemoryRegion
-	Input      MemoryRegion
-	Interrupts MemoryRegion
-	Timer      MemoryRegion
+	Memory []byte
+
+	// Emulated hardware model, which determines available IO registers
+	Model hardware.Model
+
+	// Boot ROM is mapped over cartridge ROM until it is unmapped by a write to 0xff50
+	BootROM       []byte
+	bootROMMapped bool
+
+	// Handlers for 256 byte pages below 0xff00, and for individual addresses in 0xff00-0xffff
+	pages [0xff]MemoryRegion
+	io    [0x100]MemoryRegion
 }
 
-// NewMMU is a constructor for MMU.
+// NewMMU is a constructor for MMU. Memory is in power-on state, with all IO registers cleared.
 func NewMMU() *MMU {
-	mmu := MMU{Memory: make([]byte, math.MaxUint16+1)}
+	mmu := &MMU{Memory: make([]byte, math.MaxUint16+1)}
+	mmu.Map(0xe000, 0xfdff, &echoRAM{mmu: mmu})
+	mmu.Map(0xfe00, 0xfeff, &oam{mmu: mmu})
+	registers := &systemRegisters{mmu: mmu}
+	for _, address := range []uint16{0xff44, 0xff46, 0xff4d, 0xff50} {
+		mmu.Map(address, address, registers)
+	}
+	return mmu
+}
+
+// Map registers region to handle reads and writes from start to end address, inclusive.
+// Memory below 0xff00 is mapped in 256 byte pages, so the range must consist of whole pages there.
+// IO registers and high RAM in 0xff00-0xffff can be mapped individually.
+func (mmu *MMU) Map(start uint16, end uint16, region MemoryRegion) {
+	pageAligned := start&0xff == 0 && (end >= 0xff00 || end&0xff == 0xff)
+	if start > end || (start < 0xff00 && !pageAligned) {
+		panic(fmt.Sprintf("Attempted to map invalid memory range 0x%04x-0x%04x.", start, end))
+	}
+	for address := int(start); address <= int(end); address++ {
+		if address < 0xff00 {
+			mmu.pages[address>>8] = region
+			address |= 0xff
+		} else {
+			mmu.io[address&0xff] = region
+		}
+	}
+}
+
+// MapBootROM overlays boot ROM over cartridge ROM. DMG boot ROM is 256 bytes and
+// mapped to 0x0000-0x00ff. CGB boot ROM is 2304 bytes and additionally mapped to 0x0200-0x08ff,
+// leaving cartridge header visible.
+func (mmu *MMU) MapBootROM(rom []byte) {
+	mmu.BootROM = rom
+	mmu.bootROMMapped = true
+}
+
+// SkipBoot sets IO registers to the state boot ROM of given model leaves them in.
+func (mmu *MMU) SkipBoot(model hardware.Model) {
 	mmu.Memory[0xff05] = 0x00
 	mmu.Memory[0xff06] = 0x00
 	mmu.Memory[0xff07] = 0x00
@@ -44,6 +93,7 @@ func NewMMU() *MMU {
 	mmu.Memory[0xff25] = 0xf3
 	mmu.Memory[0xff26] = 0xf1
 	mmu.Memory[0xff40] = 0x91
+	mmu.Memory[0xff41] = 0x85
 	mmu.Memory[0xff42] = 0x00
 	mmu.Memory[0xff43] = 0x00
 	mmu.Memory[0xff45] = 0x00
@@ -53,52 +103,54 @@ func NewMMU() *MMU {
 	mmu.Memory[0xff4a] = 0x00
 	mmu.Memory[0xff4b] = 0x00
 	mmu.Memory[0xffff] = 0x00
-	return &mmu
+	mmu.Memory[0xff50] = 0x01
+
+	// Color registers read as 0xff on models without them
+	if model.IsCGB() {
+		mmu.Memory[0xff4d] = 0x7e
+		mmu.Memory[0xff4f] = 0xfe
+		mmu.Memory[0xff56] = 0x3e
+		mmu.Memory[0xff70] = 0xf8
+	} else {
+		mmu.Memory[0xff4d] = 0xff
+		mmu.Memory[0xff4f] = 0xff
+		mmu.Memory[0xff56] = 0xff
+		mmu.Memory[0xff70] = 0xff
+	}
 }
 
 // Read byte from memory address.
 func (mmu *MMU) Read(address uint16) byte {
-	if address < 0x8000 {
-		return mmu.Cartridge.Read(address)
-	}  else if address == 0xff00 {
-		return mmu.Input.Read(address)
-	} else if address == 0xffff || address == 0xff0f {
-		return mmu.Interrupts.Read(address)
-	}else if address == 0xff04 || address == 0xff05 || address == 0xff06 || address == 0xff07 {
-		return mmu.Timer.Read(address)
+	if mmu.bootROMMapped && mmu.inBootROM(address) {
+		return mmu.BootROM[address]
+	}
+	if region := mmu.region(address); region != nil {
+		return region.Read(address)
 	}
 	return mmu.Memory[address]
 }
 
 // Write byte to memory address.
 func (mmu *MMU) Write(address uint16, value byte) {
-	if address < 0x8000 {
-		mmu.Cartridge.Write(address, value)
-	} else if (address >= 0xe000) && (address < 0xfe00) {
-		// Echo ram
-		mmu.Memory[address] = value
-		mmu.Write(address-0x2000, value)
-	} else if (address >= 0xfea0) && (address < 0xff00) {
-		// Restricted area
-		return
-	} else if address == 0xff44 {
-		mmu.Memory[address] = 0
-	} else if address == 0xff46 {
-		mmu.dmaTransfer(value)
-	} else if address == 0xff00 {
-		mmu.Input.Write(address, value)
-	} else if address == 0xffff || address == 0xff0f {
-		mmu.Interrupts.Write(address, value)
-	} else if address == 0xff04 || address == 0xff05 || address == 0xff06 || address == 0xff07 {
-		mmu.Timer.Write(address, value)
+	if region := mmu.region(address); region != nil {
+		region.Write(address, value)
 	} else {
 		mmu.Memory[address] = value
 	}
 }
 
-func (mmu *MMU) dmaTransfer(value byte) {
-	address := uint16(value) << 8
-	for i := uint16(0); i < 0xa0; i++ {
-		mmu.Write(0xfe00+i, mmu.Read(address+i))
+// region returns handler mapped to address, or nil for plain memory.
+func (mmu *MMU) region(address uint16) MemoryRegion {
+	if address < 0xff00 {
+		return mmu.pages[address>>8]
+	}
+	return mmu.io[address&0xff]
+}
+
+// inBootROM checks if address is within boot ROM area.
+func (mmu *MMU) inBootROM(address uint16) bool {
+	if address < 0x100 {
+		return int(address) < len(mmu.BootROM)
 	}
+	return address >= 0x200 && int(address) < len(mmu.BootROM)
 }
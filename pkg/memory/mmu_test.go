@@ -0,0 +1,132 @@
+package memory
+
+import "testing"
+
+// testRegion records the last write and returns fixed value on reads.
+type testRegion struct {
+	value        byte
+	lastAddress  uint16
+	lastWrite    byte
+	writeCounter int
+}
+
+func (region *testRegion) Read(address uint16) byte {
+	return region.value
+}
+
+func (region *testRegion) Write(address uint16, value byte) {
+	region.lastAddress = address
+	region.lastWrite = value
+	region.writeCounter++
+}
+
+func TestMapPages(t *testing.T) {
+	mmu := NewMMU()
+	rom := &testRegion{value: 0xaa}
+	mmu.Map(0x0000, 0x7fff, rom)
+
+	if value := mmu.Read(0x4123); value != 0xaa {
+		t.Errorf("Read should be handled by mapped region, got %x", value)
+	}
+	mmu.Write(0x7fff, 0x12)
+	if rom.lastAddress != 0x7fff || rom.lastWrite != 0x12 {
+		t.Errorf("Write should be handled by mapped region with full address, got %x to %x", rom.lastWrite, rom.lastAddress)
+	}
+	mmu.Write(0x8000, 0x34)
+	if rom.writeCounter != 1 || mmu.Read(0x8000) != 0x34 {
+		t.Errorf("Unmapped address should be backed by memory")
+	}
+}
+
+func TestMapIORegisters(t *testing.T) {
+	mmu := NewMMU()
+	timer := &testRegion{value: 0x55}
+	mmu.Map(0xff04, 0xff07, timer)
+
+	for address := uint16(0xff04); address <= 0xff07; address++ {
+		if value := mmu.Read(address); value != 0x55 {
+			t.Errorf("Register %x should be handled by mapped region, got %x", address, value)
+		}
+	}
+	mmu.Write(0xff08, 0x01)
+	mmu.Write(0xff03, 0x01)
+	if timer.writeCounter != 0 {
+		t.Errorf("Registers next to mapped range should not be handled by region")
+	}
+}
+
+func TestMapInvalidRange(t *testing.T) {
+	ranges := [][2]uint16{{0x0000, 0x7ffe}, {0x0001, 0x7fff}, {0xff07, 0xff04}}
+	for _, r := range ranges {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Mapping range %04x-%04x should panic", r[0], r[1])
+				}
+			}()
+			NewMMU().Map(r[0], r[1], &testRegion{})
+		}()
+	}
+}
+
+func TestEchoRAM(t *testing.T) {
+	mmu := NewMMU()
+	mmu.Write(0xc123, 0x11)
+	if value := mmu.Read(0xe123); value != 0x11 {
+		t.Errorf("Echo RAM should mirror work RAM, got %x", value)
+	}
+	mmu.Write(0xfdff, 0x22)
+	if value := mmu.Read(0xddff); value != 0x22 {
+		t.Errorf("Writes to echo RAM should reach work RAM, got %x", value)
+	}
+}
+
+func TestSystemRegisters(t *testing.T) {
+	mmu := NewMMU()
+	mmu.Write(0xfea0, 0x12)
+	if value := mmu.Read(0xfea0); value != 0 {
+		t.Errorf("Restricted area should not be writable, got %x", value)
+	}
+
+	mmu.Memory[0xff44] = 0x90
+	mmu.Write(0xff44, 0x12)
+	if value := mmu.Read(0xff44); value != 0 {
+		t.Errorf("Writing LY should reset it, got %x", value)
+	}
+
+	for i := uint16(0); i < 0xa0; i++ {
+		mmu.Write(0xc000+i, byte(i))
+	}
+	mmu.Write(0xff46, 0xc0)
+	if mmu.Read(0xfe00) != 0x00 || mmu.Read(0xfe9f) != 0x9f {
+		t.Errorf("DMA should copy source page to OAM")
+	}
+
+	boot := make([]byte, 0x100)
+	boot[0x10] = 0x31
+	rom := &testRegion{value: 0xaa}
+	mmu.Map(0x0000, 0x7fff, rom)
+	mmu.MapBootROM(boot)
+	if value := mmu.Read(0x0010); value != 0x31 {
+		t.Errorf("Boot ROM should be mapped over cartridge, got %x", value)
+	}
+	mmu.Write(0xff50, 0x01)
+	if value := mmu.Read(0x0010); value != 0xaa {
+		t.Errorf("Cartridge should be visible after boot ROM is unmapped, got %x", value)
+	}
+}
+
+func BenchmarkRead(b *testing.B) {
+	mmu := NewMMU()
+	mmu.Map(0x0000, 0x7fff, &testRegion{})
+	mmu.Map(0xa000, 0xbfff, &testRegion{})
+	mmu.Map(0xff04, 0xff07, &testRegion{})
+	addresses := []uint16{0x0150, 0x4000, 0x8000, 0xa000, 0xc000, 0xe000, 0xfe00, 0xff04, 0xff44, 0xff80}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, address := range addresses {
+			mmu.Read(address)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package memory
+
+// echoRAM mirrors work RAM at 0xc000-0xddff to 0xe000-0xfdff.
+type echoRAM struct {
+	mmu *MMU
+}
+
+func (ram *echoRAM) Read(address uint16) byte {
+	return ram.mmu.Read(address - 0x2000)
+}
+
+func (ram *echoRAM) Write(address uint16, value byte) {
+	ram.mmu.Write(address-0x2000, value)
+}
+
+// oam handles sprite attribute table at 0xfe00-0xfe9f and the unusable area after it.
+type oam struct {
+	mmu *MMU
+}
+
+func (oam *oam) Read(address uint16) byte {
+	return oam.mmu.Memory[address]
+}
+
+func (oam *oam) Write(address uint16, value byte) {
+	// Restricted area
+	if address >= 0xfea0 {
+		return
+	}
+	oam.mmu.Memory[address] = value
+}
+
+// systemRegisters handles IO registers implemented by MMU itself.
+type systemRegisters struct {
+	mmu *MMU
+}
+
+func (regs *systemRegisters) Read(address uint16) byte {
+	return regs.mmu.Memory[address]
+}
+
+func (regs *systemRegisters) Write(address uint16, value byte) {
+	mmu := regs.mmu
+	switch address {
+	case 0xff44:
+		mmu.Memory[address] = 0
+	case 0xff46:
+		mmu.dmaTransfer(value)
+	case 0xff4d:
+		// Only speed switch armed bit of KEY1 is writable, and only on CGB
+		if mmu.Model.IsCGB() {
+			mmu.Memory[address] = mmu.Memory[address]&0x80 | 0x7e | value&0x01
+		}
+	case 0xff50:
+		// Boot ROM is unmapped by writing non-zero value, after which it can't be mapped again
+		if value != 0 {
+			mmu.bootROMMapped = false
+			mmu.Memory[address] = 0x01
+		}
+	default:
+		panic("Attempted to write to system registers with invalid address.")
+	}
+}
+
+func (mmu *MMU) dmaTransfer(value byte) {
+	address := uint16(value) << 8
+	for i := uint16(0); i < 0xa0; i++ {
+		mmu.Write(0xfe00+i, mmu.Read(address+i))
+	}
+}
@@ -0,0 +1,115 @@
+package patch
+
+import "bytes"
+
+var bpsMagic = []byte("BPS1")
+
+// BPS actions encoded in the lowest 2 bits of each command.
+const (
+	bpsSourceRead = iota
+	bpsTargetRead
+	bpsSourceCopy
+	bpsTargetCopy
+)
+
+// ApplyBPS applies patch in BPS format to ROM.
+// Checksums of source ROM, patched ROM and the patch itself are verified.
+func ApplyBPS(rom []byte, patch []byte) ([]byte, error) {
+	if !bytes.HasPrefix(patch, bpsMagic) {
+		return nil, &FormatError{Format: "BPS", Offset: 0, Reason: "missing BPS1 header"}
+	}
+	footer := len(patch) - 12
+	if footer < len(bpsMagic) {
+		return nil, &FormatError{Format: "BPS", Offset: len(patch), Reason: "truncated patch"}
+	}
+	if err := verifyChecksums("BPS", rom, patch); err != nil {
+		return nil, err
+	}
+
+	data := patch[:footer]
+	pos := len(bpsMagic)
+	var sizes [3]int
+	for i := range sizes {
+		value, n, ok := decodeNumber(data, pos)
+		if !ok {
+			return nil, &FormatError{Format: "BPS", Offset: pos, Reason: "invalid header"}
+		}
+		sizes[i] = value
+		pos += n
+	}
+	sourceSize, targetSize, metadataSize := sizes[0], sizes[1], sizes[2]
+	if sourceSize != len(rom) {
+		return nil, &FormatError{Format: "BPS", Offset: len(bpsMagic), Reason: "source size doesn't match ROM size"}
+	}
+	if pos+metadataSize > footer {
+		return nil, &FormatError{Format: "BPS", Offset: pos, Reason: "truncated metadata"}
+	}
+	pos += metadataSize
+
+	if targetSize > MaxTargetSize {
+		return nil, &TargetSizeError{Format: "BPS", Size: targetSize}
+	}
+	target := make([]byte, targetSize)
+	out, sourceOffset, targetOffset := 0, 0, 0
+	for pos < footer {
+		command, n, ok := decodeNumber(data, pos)
+		if !ok {
+			return nil, &FormatError{Format: "BPS", Offset: pos, Reason: "invalid command"}
+		}
+		start := pos
+		pos += n
+		action, length := command&0x03, (command>>2)+1
+		if out+length > targetSize {
+			return nil, &FormatError{Format: "BPS", Offset: start, Reason: "write past end of target"}
+		}
+
+		switch action {
+		case bpsSourceRead:
+			if out+length > len(rom) {
+				return nil, &FormatError{Format: "BPS", Offset: start, Reason: "read past end of source"}
+			}
+			copy(target[out:], rom[out:out+length])
+		case bpsTargetRead:
+			if pos+length > footer {
+				return nil, &FormatError{Format: "BPS", Offset: start, Reason: "truncated data"}
+			}
+			copy(target[out:], data[pos:pos+length])
+			pos += length
+		case bpsSourceCopy, bpsTargetCopy:
+			value, n, ok := decodeNumber(data, pos)
+			if !ok {
+				return nil, &FormatError{Format: "BPS", Offset: pos, Reason: "invalid copy offset"}
+			}
+			pos += n
+			// Offsets are relative to the end of previous copy, with sign in the lowest bit
+			delta := value >> 1
+			if value&1 != 0 {
+				delta = -delta
+			}
+			if action == bpsSourceCopy {
+				sourceOffset += delta
+				if sourceOffset < 0 || sourceOffset+length > len(rom) {
+					return nil, &FormatError{Format: "BPS", Offset: start, Reason: "copy outside of source"}
+				}
+				copy(target[out:], rom[sourceOffset:sourceOffset+length])
+				sourceOffset += length
+			} else {
+				targetOffset += delta
+				if targetOffset < 0 || targetOffset >= out {
+					return nil, &FormatError{Format: "BPS", Offset: start, Reason: "copy outside of target"}
+				}
+				// Copy byte by byte since source and destination may overlap
+				for i := 0; i < length; i++ {
+					target[out+i] = target[targetOffset]
+					targetOffset++
+				}
+			}
+		}
+		out += length
+	}
+
+	if err := verifyTarget("BPS", target, patch); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
@@ -0,0 +1,79 @@
+package patch
+
+import (
+	"bytes"
+	"testing"
+)
+
+func bpsCommand(action, length int) []byte {
+	return encodeNumber((length-1)<<2 | action)
+}
+
+func bpsOffset(delta int) []byte {
+	if delta < 0 {
+		return encodeNumber(-delta<<1 | 1)
+	}
+	return encodeNumber(delta << 1)
+}
+
+func TestBPSActions(t *testing.T) {
+	rom := createROM()
+	var target []byte
+	target = append(target, rom[:0x10]...)
+	target = append(target, rom[0x80:0x88]...)
+	target = append(target, 'H', 'I')
+	target = append(target, 'H', 'I', 'H', 'I', 'H')
+	target = append(target, rom[0x04:0x08]...)
+
+	patch := append([]byte("BPS1"), encodeNumber(len(rom))...)
+	patch = append(patch, encodeNumber(len(target))...)
+	patch = append(patch, encodeNumber(4)...)
+	patch = append(patch, []byte("test")...)
+	patch = append(patch, bpsCommand(bpsSourceRead, 0x10)...)
+	patch = append(patch, bpsCommand(bpsSourceCopy, 8)...)
+	patch = append(patch, bpsOffset(0x80)...)
+	patch = append(patch, bpsCommand(bpsTargetRead, 2)...)
+	patch = append(patch, 'H', 'I')
+	// Overlapping copy repeats previously written bytes
+	patch = append(patch, bpsCommand(bpsTargetCopy, 5)...)
+	patch = append(patch, bpsOffset(0x18)...)
+	patch = append(patch, bpsCommand(bpsSourceCopy, 4)...)
+	patch = append(patch, bpsOffset(0x04-0x88)...)
+	patch = appendFooter(patch, rom, target)
+
+	patched, err := ApplyBPS(rom, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(patched, target) {
+		t.Errorf("Patched ROM should be %x, got %x", target, patched)
+	}
+}
+
+func TestBPSSourceSize(t *testing.T) {
+	rom := createROM()
+	patch := append([]byte("BPS1"), encodeNumber(len(rom)+1)...)
+	patch = append(patch, encodeNumber(0)...)
+	patch = append(patch, encodeNumber(0)...)
+	patch = appendFooter(patch, rom, nil)
+
+	if _, err := ApplyBPS(rom, patch); err == nil {
+		t.Error("Patch for different sized ROM should cause error")
+	} else if _, ok := err.(*FormatError); !ok {
+		t.Errorf("Error should be FormatError, got %T", err)
+	}
+}
+
+func TestBPSTargetSize(t *testing.T) {
+	rom := createROM()
+	patch := append([]byte("BPS1"), encodeNumber(len(rom))...)
+	patch = append(patch, encodeNumber(1<<32)...)
+	patch = append(patch, encodeNumber(0)...)
+	patch = appendFooter(patch, rom, nil)
+
+	if _, err := ApplyBPS(rom, patch); err == nil {
+		t.Error("Patch with huge target size should cause error")
+	} else if sizeErr, ok := err.(*TargetSizeError); !ok || sizeErr.Size != 1<<32 {
+		t.Errorf("Error should be TargetSizeError, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package patch
+
+import "fmt"
+
+// UnknownFormatError is returned when patch doesn't begin with a header of any supported format.
+type UnknownFormatError struct{}
+
+func (err *UnknownFormatError) Error() string {
+	return "unknown patch format, expected IPS, UPS or BPS"
+}
+
+// FormatError is returned when patch data is malformed or truncated.
+type FormatError struct {
+	Format string
+	Offset int
+	Reason string
+}
+
+func (err *FormatError) Error() string {
+	return fmt.Sprintf("invalid %s patch at offset 0x%x: %s", err.Format, err.Offset, err.Reason)
+}
+
+// TargetSizeError is returned when patch declares patched ROM larger than MaxTargetSize.
+type TargetSizeError struct {
+	Format string
+	Size   int
+}
+
+func (err *TargetSizeError) Error() string {
+	return fmt.Sprintf("%s patch target size %d bytes exceeds maximum ROM size of %d bytes", err.Format, err.Size, MaxTargetSize)
+}
+
+// ChecksumError is returned when CRC32 checksum stored in patch doesn't match the data.
+// Data is either "source", "target" or "patch".
+type ChecksumError struct {
+	Format   string
+	Data     string
+	Expected uint32
+	Actual   uint32
+}
+
+func (err *ChecksumError) Error() string {
+	if err.Data == "source" {
+		return fmt.Sprintf("%s patch is not meant for this ROM: checksum is %08x, expected %08x", err.Format, err.Actual, err.Expected)
+	}
+	return fmt.Sprintf("%s %s checksum mismatch: got %08x, expected %08x", err.Format, err.Data, err.Actual, err.Expected)
+}
@@ -0,0 +1,62 @@
+package patch
+
+import "bytes"
+
+var ipsMagic = []byte("PATCH")
+
+// ApplyIPS applies patch in IPS format to ROM.
+// IPS records may write past the end of ROM, in which case the result is extended.
+func ApplyIPS(rom []byte, patch []byte) ([]byte, error) {
+	if !bytes.HasPrefix(patch, ipsMagic) {
+		return nil, &FormatError{Format: "IPS", Offset: 0, Reason: "missing PATCH header"}
+	}
+	target := append([]byte{}, rom...)
+	pos := len(ipsMagic)
+	for {
+		if pos+3 > len(patch) {
+			return nil, &FormatError{Format: "IPS", Offset: pos, Reason: "missing EOF marker"}
+		}
+		if bytes.Equal(patch[pos:pos+3], []byte("EOF")) {
+			pos += 3
+			break
+		}
+		if pos+5 > len(patch) {
+			return nil, &FormatError{Format: "IPS", Offset: pos, Reason: "truncated record header"}
+		}
+		offset := int(patch[pos])<<16 | int(patch[pos+1])<<8 | int(patch[pos+2])
+		size := int(patch[pos+3])<<8 | int(patch[pos+4])
+		pos += 5
+
+		var data []byte
+		if size > 0 {
+			if pos+size > len(patch) {
+				return nil, &FormatError{Format: "IPS", Offset: pos, Reason: "truncated record data"}
+			}
+			data = patch[pos : pos+size]
+			pos += size
+		} else {
+			// Run length encoded record repeats a single byte
+			if pos+3 > len(patch) {
+				return nil, &FormatError{Format: "IPS", Offset: pos, Reason: "truncated RLE record"}
+			}
+			count := int(patch[pos])<<8 | int(patch[pos+1])
+			data = bytes.Repeat([]byte{patch[pos+2]}, count)
+			pos += 3
+		}
+		if end := offset + len(data); end > len(target) {
+			target = append(target, make([]byte, end-len(target))...)
+		}
+		copy(target[offset:], data)
+	}
+
+	// Optional truncation extension follows the EOF marker
+	if pos+3 == len(patch) {
+		size := int(patch[pos])<<16 | int(patch[pos+1])<<8 | int(patch[pos+2])
+		if size < len(target) {
+			target = target[:size]
+		}
+	} else if pos != len(patch) {
+		return nil, &FormatError{Format: "IPS", Offset: pos, Reason: "unexpected data after EOF marker"}
+	}
+	return target, nil
+}
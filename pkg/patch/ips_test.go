@@ -0,0 +1,54 @@
+package patch
+
+import "testing"
+
+func TestIPSRecords(t *testing.T) {
+	rom := createROM()
+	patch := []byte("PATCH")
+	patch = append(patch, 0x00, 0x00, 0x20, 0x00, 0x02, 0xaa, 0xbb)
+	// RLE record extending past the end of ROM
+	patch = append(patch, 0x00, 0x00, 0xfe, 0x00, 0x00, 0x00, 0x04, 0xcc)
+	patch = append(patch, []byte("EOF")...)
+
+	patched, err := ApplyIPS(rom, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched[0x20] != 0xaa || patched[0x21] != 0xbb || patched[0x22] != 0x22 {
+		t.Errorf("Record should be written at 0x20, got %x", patched[0x20:0x23])
+	}
+	if len(patched) != 0x102 {
+		t.Fatalf("Patched ROM should be extended to %x bytes, got %x", 0x102, len(patched))
+	}
+	for _, value := range patched[0xfe:] {
+		if value != 0xcc {
+			t.Errorf("RLE record should fill with %x, got %x", 0xcc, patched[0xfe:])
+			break
+		}
+	}
+}
+
+func TestIPSTruncate(t *testing.T) {
+	patched, err := ApplyIPS(createROM(), []byte("PATCHEOF\x00\x00\x80"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patched) != 0x80 {
+		t.Errorf("Patched ROM should be truncated to %x bytes, got %x", 0x80, len(patched))
+	}
+}
+
+func TestIPSErrors(t *testing.T) {
+	patches := map[string]string{
+		"missing EOF":      "PATCH\x00\x00\x10\x00\x01\xff",
+		"truncated record": "PATCH\x00\x00\x10\x00\x04\xff",
+		"trailing data":    "PATCHEOF\x00",
+	}
+	for name, patch := range patches {
+		if _, err := ApplyIPS(createROM(), []byte(patch)); err == nil {
+			t.Errorf("Patch with %s should cause error", name)
+		} else if _, ok := err.(*FormatError); !ok {
+			t.Errorf("Patch with %s should cause FormatError, got %T", name, err)
+		}
+	}
+}
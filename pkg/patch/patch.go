@@ -0,0 +1,61 @@
+// Package patch applies IPS, UPS and BPS patches to ROM images.
+package patch
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxTargetSize is the largest ROM size patches may produce, which is the size of the largest Game Boy ROM.
+const MaxTargetSize = 8 * 1024 * 1024
+
+// Extensions of supported patch files, in the order they are searched for.
+var extensions = []string{".ips", ".ups", ".bps"}
+
+// Apply detects patch format from its header and applies it to ROM.
+// ROM is not modified, patched ROM is returned as a new slice.
+func Apply(rom []byte, patch []byte) ([]byte, error) {
+	if bytes.HasPrefix(patch, ipsMagic) {
+		return ApplyIPS(rom, patch)
+	} else if bytes.HasPrefix(patch, upsMagic) {
+		return ApplyUPS(rom, patch)
+	} else if bytes.HasPrefix(patch, bpsMagic) {
+		return ApplyBPS(rom, patch)
+	}
+	return nil, &UnknownFormatError{}
+}
+
+// FindPatch looks for a patch file with the same name as ROM file and returns its path.
+// Second return value is false when no patch file exists.
+func FindPatch(romFile string) (string, bool) {
+	base := strings.TrimSuffix(romFile, filepath.Ext(romFile))
+	for _, ext := range extensions {
+		path := base + ext
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// decodeNumber reads variable length number used by UPS and BPS formats.
+// It returns the number and the count of bytes read.
+func decodeNumber(data []byte, offset int) (int, int, bool) {
+	value, shift := 0, 1
+	for i := offset; i < len(data); i++ {
+		x := int(data[i])
+		value += (x & 0x7f) * shift
+		if x&0x80 != 0 {
+			return value, i - offset + 1, true
+		}
+		shift <<= 7
+		value += shift
+		// Numbers this large can't be valid ROM sizes or offsets
+		if shift > 1<<28 {
+			break
+		}
+	}
+	return 0, 0, false
+}
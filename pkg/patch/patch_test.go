@@ -0,0 +1,124 @@
+package patch
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createROM() []byte {
+	rom := make([]byte, 0x100)
+	for i := range rom {
+		rom[i] = byte(i)
+	}
+	return rom
+}
+
+// encodeNumber encodes variable length number used by UPS and BPS formats.
+func encodeNumber(n int) []byte {
+	var data []byte
+	for {
+		x := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(data, 0x80|x)
+		}
+		data = append(data, x)
+		n--
+	}
+}
+
+// appendFooter appends source, target and patch checksums to UPS or BPS patch.
+func appendFooter(patch, source, target []byte) []byte {
+	var checksum [4]byte
+	binary.LittleEndian.PutUint32(checksum[:], crc32.ChecksumIEEE(source))
+	patch = append(patch, checksum[:]...)
+	binary.LittleEndian.PutUint32(checksum[:], crc32.ChecksumIEEE(target))
+	patch = append(patch, checksum[:]...)
+	binary.LittleEndian.PutUint32(checksum[:], crc32.ChecksumIEEE(patch))
+	return append(patch, checksum[:]...)
+}
+
+func TestNumberEncoding(t *testing.T) {
+	for _, n := range []int{0, 1, 0x7f, 0x80, 0x407f, 0x4080, 0x123456} {
+		data := encodeNumber(n)
+		value, size, ok := decodeNumber(data, 0)
+		if !ok || value != n || size != len(data) {
+			t.Errorf("Number %x should decode from %x, got %x (%d bytes)", n, data, value, size)
+		}
+	}
+	if _, _, ok := decodeNumber([]byte{0x00, 0x01}, 0); ok {
+		t.Error("Unterminated number should not decode")
+	}
+}
+
+func TestApplyDetectsFormat(t *testing.T) {
+	rom := createROM()
+	ips := []byte("PATCH\x00\x00\x10\x00\x01\xffEOF")
+	patched, err := Apply(rom, ips)
+	if err != nil {
+		t.Fatalf("IPS patch should apply, got %v", err)
+	}
+	if patched[0x10] != 0xff {
+		t.Errorf("Patched value should be %x, got %x", 0xff, patched[0x10])
+	}
+	if rom[0x10] != 0x10 {
+		t.Error("Original ROM should not be modified")
+	}
+
+	if _, err := Apply(rom, []byte("NOTAPATCH")); err == nil {
+		t.Error("Unknown patch format should cause error")
+	} else if _, ok := err.(*UnknownFormatError); !ok {
+		t.Errorf("Error should be UnknownFormatError, got %T", err)
+	}
+}
+
+func TestFindPatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	romFile := filepath.Join(dir, "game.gb")
+
+	if _, ok := FindPatch(romFile); ok {
+		t.Error("Patch should not be found when it doesn't exist")
+	}
+	patchFile := filepath.Join(dir, "game.bps")
+	if err := ioutil.WriteFile(patchFile, []byte("BPS1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if path, ok := FindPatch(romFile); !ok || path != patchFile {
+		t.Errorf("Patch should be found at %s, got %s", patchFile, path)
+	}
+}
+
+func TestChecksumErrors(t *testing.T) {
+	rom := createROM()
+	target := append([]byte{}, rom...)
+	target[0] = 0xaa
+	patch := append([]byte("UPS1"), encodeNumber(len(rom))...)
+	patch = append(patch, encodeNumber(len(target))...)
+	patch = append(patch, encodeNumber(0)...)
+	patch = append(patch, 0xaa, 0x00)
+	patch = appendFooter(patch, rom, target)
+
+	other := createROM()
+	other[0x80] = 0
+	if _, err := ApplyUPS(other, patch); err == nil {
+		t.Error("Patch should not apply to different ROM")
+	} else if checksumErr, ok := err.(*ChecksumError); !ok || checksumErr.Data != "source" {
+		t.Errorf("Error should be source ChecksumError, got %v", err)
+	}
+
+	corrupted := append([]byte{}, patch...)
+	corrupted[len(corrupted)-14] ^= 0xff
+	if _, err := ApplyUPS(rom, corrupted); err == nil {
+		t.Error("Corrupted patch should not apply")
+	} else if checksumErr, ok := err.(*ChecksumError); !ok || checksumErr.Data != "patch" {
+		t.Errorf("Error should be patch ChecksumError, got %v", err)
+	}
+}
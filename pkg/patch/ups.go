@@ -0,0 +1,102 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+)
+
+var upsMagic = []byte("UPS1")
+
+// ApplyUPS applies patch in UPS format to ROM.
+// Checksums of source ROM, patched ROM and the patch itself are verified.
+func ApplyUPS(rom []byte, patch []byte) ([]byte, error) {
+	if !bytes.HasPrefix(patch, upsMagic) {
+		return nil, &FormatError{Format: "UPS", Offset: 0, Reason: "missing UPS1 header"}
+	}
+	footer := len(patch) - 12
+	if footer < len(upsMagic) {
+		return nil, &FormatError{Format: "UPS", Offset: len(patch), Reason: "truncated patch"}
+	}
+	if err := verifyChecksums("UPS", rom, patch); err != nil {
+		return nil, err
+	}
+
+	pos := len(upsMagic)
+	sourceSize, n, ok := decodeNumber(patch[:footer], pos)
+	if !ok {
+		return nil, &FormatError{Format: "UPS", Offset: pos, Reason: "invalid source size"}
+	}
+	pos += n
+	targetSize, n, ok := decodeNumber(patch[:footer], pos)
+	if !ok {
+		return nil, &FormatError{Format: "UPS", Offset: pos, Reason: "invalid target size"}
+	}
+	pos += n
+	if sourceSize != len(rom) {
+		return nil, &FormatError{Format: "UPS", Offset: len(upsMagic), Reason: "source size doesn't match ROM size"}
+	}
+
+	if targetSize > MaxTargetSize {
+		return nil, &TargetSizeError{Format: "UPS", Size: targetSize}
+	}
+	target := make([]byte, targetSize)
+	copy(target, rom)
+	out := 0
+	for pos < footer {
+		skip, n, ok := decodeNumber(patch[:footer], pos)
+		if !ok {
+			return nil, &FormatError{Format: "UPS", Offset: pos, Reason: "invalid hunk offset"}
+		}
+		pos += n
+		out += skip
+		// Hunk contains bytes XORed with source, terminated by zero
+		for {
+			if pos >= footer {
+				return nil, &FormatError{Format: "UPS", Offset: pos, Reason: "unterminated hunk"}
+			}
+			x := patch[pos]
+			pos++
+			if out < targetSize {
+				var source byte
+				if out < len(rom) {
+					source = rom[out]
+				}
+				target[out] = source ^ x
+			}
+			out++
+			if x == 0 {
+				break
+			}
+		}
+	}
+
+	if err := verifyTarget("UPS", target, patch); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// verifyChecksums checks CRC32 of the patch and source ROM from the 12 byte footer
+// shared by UPS and BPS formats.
+func verifyChecksums(format string, rom []byte, patch []byte) error {
+	footer := len(patch) - 12
+	expected := binary.LittleEndian.Uint32(patch[footer+8:])
+	if actual := crc32.ChecksumIEEE(patch[:footer+8]); actual != expected {
+		return &ChecksumError{Format: format, Data: "patch", Expected: expected, Actual: actual}
+	}
+	expected = binary.LittleEndian.Uint32(patch[footer:])
+	if actual := crc32.ChecksumIEEE(rom); actual != expected {
+		return &ChecksumError{Format: format, Data: "source", Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// verifyTarget checks CRC32 of patched ROM from the footer.
+func verifyTarget(format string, target []byte, patch []byte) error {
+	expected := binary.LittleEndian.Uint32(patch[len(patch)-8:])
+	if actual := crc32.ChecksumIEEE(target); actual != expected {
+		return &ChecksumError{Format: format, Data: "target", Expected: expected, Actual: actual}
+	}
+	return nil
+}
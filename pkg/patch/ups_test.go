@@ -0,0 +1,62 @@
+package patch
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUPSHunks(t *testing.T) {
+	rom := createROM()
+	target := append([]byte{}, rom...)
+	target[0x05] = 0xff
+	target[0x06] = 0x00
+	target[0x40] = 0x41
+	target = append(target, 0x12, 0x34)
+
+	patch := append([]byte("UPS1"), encodeNumber(len(rom))...)
+	patch = append(patch, encodeNumber(len(target))...)
+	patch = append(patch, encodeNumber(0x05)...)
+	patch = append(patch, 0x05^0xff, 0x06, 0x00)
+	// Offset is relative to the byte after the previous hunk terminator
+	patch = append(patch, encodeNumber(0x40-0x08)...)
+	patch = append(patch, 0x40^0x41, 0x00)
+	patch = append(patch, encodeNumber(0x100-0x42)...)
+	patch = append(patch, 0x12, 0x34, 0x00)
+	patch = appendFooter(patch, rom, target)
+
+	patched, err := ApplyUPS(rom, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(patched, target) {
+		t.Errorf("Patched ROM doesn't match target")
+	}
+}
+
+func TestUPSTargetChecksum(t *testing.T) {
+	rom := createROM()
+	patch := append([]byte("UPS1"), encodeNumber(len(rom))...)
+	patch = append(patch, encodeNumber(len(rom))...)
+	patch = append(patch, encodeNumber(0)...)
+	patch = append(patch, 0x01, 0x00)
+	patch = appendFooter(patch, rom, rom)
+
+	if _, err := ApplyUPS(rom, patch); err == nil {
+		t.Error("Patch producing unexpected target should cause error")
+	} else if checksumErr, ok := err.(*ChecksumError); !ok || checksumErr.Data != "target" {
+		t.Errorf("Error should be target ChecksumError, got %v", err)
+	}
+}
+
+func TestUPSTargetSize(t *testing.T) {
+	rom := createROM()
+	patch := append([]byte("UPS1"), encodeNumber(len(rom))...)
+	patch = append(patch, encodeNumber(1<<32)...)
+	patch = appendFooter(patch, rom, nil)
+
+	if _, err := ApplyUPS(rom, patch); err == nil {
+		t.Error("Patch with huge target size should cause error")
+	} else if sizeErr, ok := err.(*TargetSizeError); !ok || sizeErr.Size != 1<<32 {
+		t.Errorf("Error should be TargetSizeError, got %v", err)
+	}
+}
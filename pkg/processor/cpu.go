@@ -1,6 +1,7 @@
 package processor
 
 import (
+	"github.com/v4t/gomb/pkg/hardware"
 	"github.com/v4t/gomb/pkg/memory"
 )
 
@@ -29,39 +30,68 @@ type CPU struct {
 	PC         uint16
 	SP         uint16
 	Halted     bool
-	Stopped    bool
+	Stopped    bool // CPU and LCD don't run in stop mode until a button is pressed
+	Locked     bool // CPU is locked up by illegal opcode
 
-	haltBug             bool
-	enablingInterrupts  bool
-	disablingInterrupts bool
+	// CGB double speed mode, in which CPU runs at twice the clock of PPU
+	DoubleSpeed bool
+
+	// Tick advances components running alongside CPU by given amount of clock cycles.
+	// It is called for every machine cycle of an instruction before its memory access,
+	// so that reads and writes see timer and PPU state at the right point of the instruction.
+	Tick func(cycles int)
+
+	// Machine cycles ticked during current instruction
+	cycles int
+	// Reason for CPU lock up
+	lockUp *IllegalOpcodeError
+
+	haltBug            bool
+	branchTaken        bool
+	enablingInterrupts bool
 }
 
-// NewCPU is a constructor for CPU.
+// NewCPU is a constructor for CPU. CPU is in power-on state, with all registers
+// cleared and execution starting from boot ROM at 0x0000.
 func NewCPU() *CPU {
 	cpu := &CPU{
 		MMU:        memory.NewMMU(),
 		Interrupts: NewInterrupts(),
-		Registers: Registers{
-			A: 0x11,
-			B: 0x00,
-			C: 0x00,
-			D: 0xff,
-			E: 0x56,
-			H: 0x00,
-			L: 0x0d,
-			F: 0x80,
-		},
-		Clock: Clock{machine: 0, cpu: 0},
-		PC:    0x100,
-		SP:    0xfffe,
+		Clock:      Clock{machine: 0, cpu: 0},
 	}
 	return cpu
 }
 
+// SkipBoot sets registers to the state boot ROM of given model leaves them in,
+// with execution starting from cartridge entry point at 0x0100.
+func (cpu *CPU) SkipBoot(model hardware.Model) {
+	regs := model.PostBootRegisters()
+	cpu.Registers = Registers{
+		A: regs.A,
+		B: regs.B,
+		C: regs.C,
+		D: regs.D,
+		E: regs.E,
+		H: regs.H,
+		L: regs.L,
+		F: regs.F,
+	}
+	cpu.PC = 0x100
+	cpu.SP = 0xfffe
+}
+
+// Err returns the error which locked up CPU, or nil if CPU is running.
+func (cpu *CPU) Err() error {
+	if cpu.lockUp == nil {
+		return nil
+	}
+	return cpu.lockUp
+}
+
 // Execute next CPU cycle.
 func (cpu *CPU) Execute() int {
 	enableIrq := cpu.enablingInterrupts
-	disableIrq := cpu.disablingInterrupts
+	cpu.cycles = 0
 	op := cpu.Fetch()
 	cycles := 0
 
@@ -72,20 +102,21 @@ func (cpu *CPU) Execute() int {
 	} else {
 		cycles += ExecuteInstruction(cpu, op)
 	}
-	if enableIrq {
+	// Remaining internal cycles are spent at the end of instruction
+	for cpu.cycles < cycles {
+		cpu.tick()
+	}
+	// EI takes effect after the following instruction, unless it was DI
+	if enableIrq && cpu.enablingInterrupts {
 		cpu.Interrupts.Enable()
 		cpu.enablingInterrupts = false
 	}
-	if disableIrq {
-		cpu.Interrupts.Disable()
-		cpu.disablingInterrupts = false
-	}
 	return cycles * 4
 }
 
 // Fetch retrieve next byte from memory.
 func (cpu *CPU) Fetch() byte {
-	op := cpu.MMU.Read(cpu.PC)
+	op := cpu.read(cpu.PC)
 	if cpu.haltBug {
 		cpu.haltBug = false
 		return op
@@ -102,6 +133,26 @@ func (cpu *CPU) Fetch16() uint16 {
 	return j<<8 | i
 }
 
+// read byte from memory, taking one machine cycle.
+func (cpu *CPU) read(address uint16) byte {
+	cpu.tick()
+	return cpu.MMU.Read(address)
+}
+
+// write byte to memory, taking one machine cycle.
+func (cpu *CPU) write(address uint16, value byte) {
+	cpu.tick()
+	cpu.MMU.Write(address, value)
+}
+
+// tick advances other components by one machine cycle.
+func (cpu *CPU) tick() {
+	cpu.cycles++
+	if cpu.Tick != nil {
+		cpu.Tick(4)
+	}
+}
+
 // Carry retrieves carry flag.
 func (cpu *CPU) Carry() bool {
 	return cpu.Registers.F&bitflagC != 0
@@ -157,9 +208,3 @@ func (cpu *CPU) SetZero(value bool) {
 		cpu.Registers.F &^= bitflagZ
 	}
 }
-
-func (cpu *CPU) pushPC() {
-	cpu.MMU.Write(cpu.SP-1, byte(uint16(cpu.PC&0xff00)>>8))
-	cpu.MMU.Write(cpu.SP-2, byte(cpu.PC&0xff))
-	cpu.SP -= 2
-}
@@ -0,0 +1,13 @@
+package processor
+
+import "fmt"
+
+// IllegalOpcodeError is reported when CPU locks up after executing an opcode that doesn't exist.
+type IllegalOpcodeError struct {
+	Opcode  byte
+	Address uint16
+}
+
+func (err *IllegalOpcodeError) Error() string {
+	return fmt.Sprintf("CPU locked up by illegal opcode 0x%02x at 0x%04x", err.Opcode, err.Address)
+}
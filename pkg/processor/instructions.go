@@ -1,319 +1,336 @@
 package processor
 
-var instructions []func()
+// instructions contains basic CPU instructions indexed by opcode. Instructions
+// don't hold any state, so the same table is shared by all CPU instances.
+var instructions [0x100]func(cpu *CPU)
+
+func init() {
+	initInstructionList()
+	initCBInstructionList()
+}
 
 // ExecuteInstruction executes given operation and returns the amount of cycles taken.
 func ExecuteInstruction(cpu *CPU, opCode byte) int {
-	if len(instructions) != 0x100 {
-		initInstructionList(cpu)
+	cpu.branchTaken = false
+	instructions[opCode](cpu)
+	if cpu.branchTaken {
+		return InstructionCycles[opCode] + BranchCycles[opCode]
 	}
-	instructions[opCode]()
 	return InstructionCycles[opCode]
 }
 
 // InstructionCycles contains the amount of cpu cycles taken per instruction
 var InstructionCycles = [0x100]int{
 	1, 3, 2, 2, 1, 1, 2, 1, 5, 2, 2, 2, 1, 1, 2, 1,
-	0, 3, 2, 2, 1, 1, 2, 1, 3, 2, 2, 2, 1, 1, 2, 1,
+	1, 3, 2, 2, 1, 1, 2, 1, 3, 2, 2, 2, 1, 1, 2, 1,
 	2, 3, 2, 2, 1, 1, 2, 1, 2, 2, 2, 2, 1, 1, 2, 1,
 	2, 3, 2, 2, 3, 3, 3, 1, 2, 2, 2, 2, 1, 1, 2, 1,
 	1, 1, 1, 1, 1, 1, 2, 1, 1, 1, 1, 1, 1, 1, 2, 1,
 	1, 1, 1, 1, 1, 1, 2, 1, 1, 1, 1, 1, 1, 1, 2, 1,
 	1, 1, 1, 1, 1, 1, 2, 1, 1, 1, 1, 1, 1, 1, 2, 1,
-	2, 2, 2, 2, 2, 2, 0, 2, 1, 1, 1, 1, 1, 1, 2, 1,
+	2, 2, 2, 2, 2, 2, 1, 2, 1, 1, 1, 1, 1, 1, 2, 1,
 	1, 1, 1, 1, 1, 1, 2, 1, 1, 1, 1, 1, 1, 1, 2, 1,
 	1, 1, 1, 1, 1, 1, 2, 1, 1, 1, 1, 1, 1, 1, 2, 1,
 	1, 1, 1, 1, 1, 1, 2, 1, 1, 1, 1, 1, 1, 1, 2, 1,
 	1, 1, 1, 1, 1, 1, 2, 1, 1, 1, 1, 1, 1, 1, 2, 1,
 	2, 3, 3, 4, 3, 4, 2, 4, 2, 4, 3, 0, 3, 6, 2, 4,
-	2, 3, 3, 0, 3, 4, 2, 4, 2, 4, 3, 0, 3, 0, 2, 4,
-	3, 3, 2, 0, 0, 4, 2, 4, 4, 1, 4, 0, 0, 0, 2, 4,
-	3, 3, 2, 1, 0, 4, 2, 4, 3, 2, 4, 1, 0, 0, 2, 4,
+	2, 3, 3, 1, 3, 4, 2, 4, 2, 4, 3, 1, 3, 1, 2, 4,
+	3, 3, 2, 1, 1, 4, 2, 4, 4, 1, 4, 1, 1, 1, 2, 4,
+	3, 3, 2, 1, 1, 4, 2, 4, 3, 2, 4, 1, 1, 1, 2, 4,
+}
+
+// BranchCycles contains the amount of additional cpu cycles taken by conditional
+// jumps, calls and returns when the condition is true.
+var BranchCycles = [0x100]int{
+	0x20: 1, 0x28: 1, 0x30: 1, 0x38: 1, // JR cc,n
+	0xc2: 1, 0xca: 1, 0xd2: 1, 0xda: 1, // JP cc,nn
+	0xc4: 3, 0xcc: 3, 0xd4: 3, 0xdc: 3, // CALL cc,nn
+	0xc0: 3, 0xc8: 3, 0xd0: 3, 0xd8: 3, // RET cc
 }
 
 // Initialize list of basic CPU instructions.
-func initInstructionList(cpu *CPU) {
-	instructions = make([]func(), 0x100)
-
-	instructions[0x00] = nop                                                           // NOP
-	instructions[0x01] = func() { cpu.Registers.SetBC(cpu.Fetch16()) }                 // LD BC,nn
-	instructions[0x02] = func() { cpu.MMU.Write(cpu.Registers.BC(), cpu.Registers.A) } // LD (BC),A
-	instructions[0x03] = func() { incNN(cpu, cpu.Registers.BC, cpu.Registers.SetBC) }  // INC BC
-	instructions[0x04] = func() { incN(cpu, &cpu.Registers.B) }                        // INC B
-	instructions[0x05] = func() { decN(cpu, &cpu.Registers.B) }                        // DEC B
-	instructions[0x06] = func() { cpu.Registers.B = cpu.Fetch() }                      // LD B,n
-	instructions[0x07] = func() { rlca(cpu) }                                          // RLCA
-	instructions[0x08] = func() { ldNNSP(cpu) }                                        // LD (nn),SP
-	instructions[0x09] = func() { addHL(cpu, cpu.Registers.BC()) }                     // ADD HL,BC
-	instructions[0x0a] = func() { cpu.Registers.A = cpu.MMU.Read(cpu.Registers.BC()) } // LD A,(BC)
-	instructions[0x0b] = func() { decNN(cpu, cpu.Registers.BC, cpu.Registers.SetBC) }  // DEC BC
-	instructions[0x0c] = func() { incN(cpu, &cpu.Registers.C) }                        // INC C
-	instructions[0x0d] = func() { decN(cpu, &cpu.Registers.C) }                        // DEC C
-	instructions[0x0e] = func() { cpu.Registers.C = cpu.Fetch() }                      // LD C,n
-	instructions[0x0f] = func() { rrca(cpu) }                                          // RRCA
-
-	instructions[0x10] = func() { stop(cpu) }                                          // STOP
-	instructions[0x11] = func() { cpu.Registers.SetDE(cpu.Fetch16()) }                 // LD DE,nn
-	instructions[0x12] = func() { cpu.MMU.Write(cpu.Registers.DE(), cpu.Registers.A) } // LD (DE),A
-	instructions[0x13] = func() { incNN(cpu, cpu.Registers.DE, cpu.Registers.SetDE) }  // INC DE
-	instructions[0x14] = func() { incN(cpu, &cpu.Registers.D) }                        // INC D
-	instructions[0x15] = func() { decN(cpu, &cpu.Registers.D) }                        // DEC D
-	instructions[0x16] = func() { cpu.Registers.D = cpu.Fetch() }                      // LD D,n
-	instructions[0x17] = func() { rla(cpu) }                                           // RLA
-	instructions[0x18] = func() { jr(cpu, int8(cpu.Fetch())) }                         // JR n
-	instructions[0x19] = func() { addHL(cpu, cpu.Registers.DE()) }                     // ADD HL,DE
-	instructions[0x1a] = func() { cpu.Registers.A = cpu.MMU.Read(cpu.Registers.DE()) } // LD A,(DE)
-	instructions[0x1b] = func() { decNN(cpu, cpu.Registers.DE, cpu.Registers.SetDE) }  // DEC DE
-	instructions[0x1c] = func() { incN(cpu, &cpu.Registers.E) }                        // INC E
-	instructions[0x1d] = func() { decN(cpu, &cpu.Registers.E) }                        // DEC E
-	instructions[0x1e] = func() { cpu.Registers.E = cpu.Fetch() }                      // LD E,n
-	instructions[0x1f] = func() { rra(cpu) }                                           // RRA
-
-	instructions[0x20] = func() { jrCC(cpu, !cpu.Zero(), int8(cpu.Fetch())) }         // JP NZ,*
-	instructions[0x21] = func() { cpu.Registers.SetHL(cpu.Fetch16()) }                // LD HL,nn
-	instructions[0x22] = func() { ldiHLA(cpu) }                                       // LDI (HL),A
-	instructions[0x23] = func() { incNN(cpu, cpu.Registers.HL, cpu.Registers.SetHL) } // INC HL
-	instructions[0x24] = func() { incN(cpu, &cpu.Registers.H) }                       // INC H
-	instructions[0x25] = func() { decN(cpu, &cpu.Registers.H) }                       // DEC H
-	instructions[0x26] = func() { cpu.Registers.H = cpu.Fetch() }                     // LD H,n
-	instructions[0x27] = func() { daa(cpu) }                                          // DAA
-	instructions[0x28] = func() { jrCC(cpu, cpu.Zero(), int8(cpu.Fetch())) }          // JP Z,*
-	instructions[0x29] = func() { addHL(cpu, cpu.Registers.HL()) }                    // ADD HL,HL
-	instructions[0x2a] = func() { ldiAHL(cpu) }                                       // LDI A,(HL)
-	instructions[0x2b] = func() { decNN(cpu, cpu.Registers.HL, cpu.Registers.SetHL) } // DEC HL
-	instructions[0x2c] = func() { incN(cpu, &cpu.Registers.L) }                       // INC L
-	instructions[0x2d] = func() { decN(cpu, &cpu.Registers.L) }                       // DEC L
-	instructions[0x2e] = func() { cpu.Registers.L = cpu.Fetch() }                     // LD L,n
-	instructions[0x2f] = func() { cpl(cpu) }                                          // CPL
-
-	instructions[0x30] = func() { jrCC(cpu, !cpu.Carry(), int8(cpu.Fetch())) }     // JP NC,*
-	instructions[0x31] = func() { cpu.SP = cpu.Fetch16() }                         // LD SP,nn
-	instructions[0x32] = func() { lddHLA(cpu) }                                    // LDD (HL),A
-	instructions[0x33] = func() { cpu.SP++ }                                       // INC SP
-	instructions[0x34] = func() { incHL(cpu) }                                     // INC (HL)
-	instructions[0x35] = func() { decHL(cpu) }                                     // DEC (HL)
-	instructions[0x36] = func() { cpu.MMU.Write(cpu.Registers.HL(), cpu.Fetch()) } // LD (HL),n
-	instructions[0x37] = func() { scf(cpu) }                                       // SCF
-	instructions[0x38] = func() { jrCC(cpu, cpu.Carry(), int8(cpu.Fetch())) }      // JP C,*
-	instructions[0x39] = func() { addHL(cpu, cpu.SP) }                             // ADD HL,SP
-	instructions[0x3a] = func() { lddAHL(cpu) }                                    // LDD A,(HL)
-	instructions[0x3b] = func() { cpu.SP-- }                                       // DEC SP
-	instructions[0x3c] = func() { incN(cpu, &cpu.Registers.A) }                    // INC A
-	instructions[0x3d] = func() { decN(cpu, &cpu.Registers.A) }                    // DEC A
-	instructions[0x3e] = func() { cpu.Registers.A = cpu.Fetch() }                  // LD A,#
-	instructions[0x3f] = func() { ccf(cpu) }                                       // CCF
-
-	instructions[0x40] = func() {}                                                     // LD B,B
-	instructions[0x41] = func() { cpu.Registers.B = cpu.Registers.C }                  // LD B,C
-	instructions[0x42] = func() { cpu.Registers.B = cpu.Registers.D }                  // LD B,D
-	instructions[0x43] = func() { cpu.Registers.B = cpu.Registers.E }                  // LD B,E
-	instructions[0x44] = func() { cpu.Registers.B = cpu.Registers.H }                  // LD B,H
-	instructions[0x45] = func() { cpu.Registers.B = cpu.Registers.L }                  // LD B,L
-	instructions[0x46] = func() { cpu.Registers.B = cpu.MMU.Read(cpu.Registers.HL()) } // LD B,(HL)
-	instructions[0x47] = func() { cpu.Registers.B = cpu.Registers.A }                  // LD B,A
-	instructions[0x48] = func() { cpu.Registers.C = cpu.Registers.B }                  // LD C,B
-	instructions[0x49] = func() {}                                                     // LD C,C
-	instructions[0x4a] = func() { cpu.Registers.C = cpu.Registers.D }                  // LD C,D
-	instructions[0x4b] = func() { cpu.Registers.C = cpu.Registers.E }                  // LD C,E
-	instructions[0x4c] = func() { cpu.Registers.C = cpu.Registers.H }                  // LD C,H
-	instructions[0x4d] = func() { cpu.Registers.C = cpu.Registers.L }                  // LD C,L
-	instructions[0x4e] = func() { cpu.Registers.C = cpu.MMU.Read(cpu.Registers.HL()) } // LD C,(HL)
-	instructions[0x4f] = func() { cpu.Registers.C = cpu.Registers.A }                  // LD C,A
-
-	instructions[0x50] = func() { cpu.Registers.D = cpu.Registers.B }                  // LD D,B
-	instructions[0x51] = func() { cpu.Registers.D = cpu.Registers.C }                  // LD D,C
-	instructions[0x52] = func() {}                                                     // LD D,D
-	instructions[0x53] = func() { cpu.Registers.D = cpu.Registers.E }                  // LD D,E
-	instructions[0x54] = func() { cpu.Registers.D = cpu.Registers.H }                  // LD D,H
-	instructions[0x55] = func() { cpu.Registers.D = cpu.Registers.L }                  // LD D,L
-	instructions[0x56] = func() { cpu.Registers.D = cpu.MMU.Read(cpu.Registers.HL()) } // LD D,(HL)
-	instructions[0x57] = func() { cpu.Registers.D = cpu.Registers.A }                  // LD D,A
-	instructions[0x58] = func() { cpu.Registers.E = cpu.Registers.B }                  // LD E,B
-	instructions[0x59] = func() { cpu.Registers.E = cpu.Registers.C }                  // LD E,C
-	instructions[0x5a] = func() { cpu.Registers.E = cpu.Registers.D }                  // LD E,D
-	instructions[0x5b] = func() {}                                                     // LD E,E
-	instructions[0x5c] = func() { cpu.Registers.E = cpu.Registers.H }                  // LD E,H
-	instructions[0x5d] = func() { cpu.Registers.E = cpu.Registers.L }                  // LD E,L
-	instructions[0x5e] = func() { cpu.Registers.E = cpu.MMU.Read(cpu.Registers.HL()) } // LD E,(HL)
-	instructions[0x5f] = func() { cpu.Registers.E = cpu.Registers.A }                  // LD E,A
-
-	instructions[0x60] = func() { cpu.Registers.H = cpu.Registers.B }                  // LD H,B
-	instructions[0x61] = func() { cpu.Registers.H = cpu.Registers.C }                  // LD H,C
-	instructions[0x62] = func() { cpu.Registers.H = cpu.Registers.D }                  // LD H,D
-	instructions[0x63] = func() { cpu.Registers.H = cpu.Registers.E }                  // LD H,E
-	instructions[0x64] = func() {}                                                     // LD H,H
-	instructions[0x65] = func() { cpu.Registers.H = cpu.Registers.L }                  // LD H,L
-	instructions[0x66] = func() { cpu.Registers.H = cpu.MMU.Read(cpu.Registers.HL()) } // LD H,(HL)
-	instructions[0x67] = func() { cpu.Registers.H = cpu.Registers.A }                  // LD H,A
-	instructions[0x68] = func() { cpu.Registers.L = cpu.Registers.B }                  // LD L,B
-	instructions[0x69] = func() { cpu.Registers.L = cpu.Registers.C }                  // LD L,C
-	instructions[0x6a] = func() { cpu.Registers.L = cpu.Registers.D }                  // LD L,D
-	instructions[0x6b] = func() { cpu.Registers.L = cpu.Registers.E }                  // LD L,E
-	instructions[0x6c] = func() { cpu.Registers.L = cpu.Registers.H }                  // LD L,H
-	instructions[0x6d] = func() {}                                                     // LD L,L
-	instructions[0x6e] = func() { cpu.Registers.L = cpu.MMU.Read(cpu.Registers.HL()) } // LD L,(HL)
-	instructions[0x6f] = func() { cpu.Registers.L = cpu.Registers.A }                  // LD L,A
-
-	instructions[0x70] = func() { cpu.MMU.Write(cpu.Registers.HL(), cpu.Registers.B) } // LD (HL),B
-	instructions[0x71] = func() { cpu.MMU.Write(cpu.Registers.HL(), cpu.Registers.C) } // LD (HL),C
-	instructions[0x72] = func() { cpu.MMU.Write(cpu.Registers.HL(), cpu.Registers.D) } // LD (HL),D
-	instructions[0x73] = func() { cpu.MMU.Write(cpu.Registers.HL(), cpu.Registers.E) } // LD (HL),E
-	instructions[0x74] = func() { cpu.MMU.Write(cpu.Registers.HL(), cpu.Registers.H) } // LD (HL),H
-	instructions[0x75] = func() { cpu.MMU.Write(cpu.Registers.HL(), cpu.Registers.L) } // LD (HL),L
-	instructions[0x76] = func() { halt(cpu) }                                          // HALT
-	instructions[0x77] = func() { cpu.MMU.Write(cpu.Registers.HL(), cpu.Registers.A) } // LD (HL),A
-	instructions[0x78] = func() { cpu.Registers.A = cpu.Registers.B }                  // LD A,B
-	instructions[0x79] = func() { cpu.Registers.A = cpu.Registers.C }                  // LD A,C
-	instructions[0x7a] = func() { cpu.Registers.A = cpu.Registers.D }                  // LD A,D
-	instructions[0x7b] = func() { cpu.Registers.A = cpu.Registers.E }                  // LD A,E
-	instructions[0x7c] = func() { cpu.Registers.A = cpu.Registers.H }                  // LD A,H
-	instructions[0x7d] = func() { cpu.Registers.A = cpu.Registers.L }                  // LD A,L
-	instructions[0x7e] = func() { cpu.Registers.A = cpu.MMU.Read(cpu.Registers.HL()) } // LD A,(HL)
-	instructions[0x7f] = func() {}                                                     // LD A,A
-
-	instructions[0x80] = func() { add(cpu, cpu.Registers.B) }                  // ADD A,B
-	instructions[0x81] = func() { add(cpu, cpu.Registers.C) }                  // ADD A,C
-	instructions[0x82] = func() { add(cpu, cpu.Registers.D) }                  // ADD A,D
-	instructions[0x83] = func() { add(cpu, cpu.Registers.E) }                  // ADD A,E
-	instructions[0x84] = func() { add(cpu, cpu.Registers.H) }                  // ADD A,H
-	instructions[0x85] = func() { add(cpu, cpu.Registers.L) }                  // ADD A,L
-	instructions[0x86] = func() { add(cpu, cpu.MMU.Read(cpu.Registers.HL())) } // ADD A,(HL)
-	instructions[0x87] = func() { add(cpu, cpu.Registers.A) }                  // ADD A,A
-	instructions[0x88] = func() { adc(cpu, cpu.Registers.B) }                  // ADC A,B
-	instructions[0x89] = func() { adc(cpu, cpu.Registers.C) }                  // ADC A,C
-	instructions[0x8a] = func() { adc(cpu, cpu.Registers.D) }                  // ADC A,D
-	instructions[0x8b] = func() { adc(cpu, cpu.Registers.E) }                  // ADC A,E
-	instructions[0x8c] = func() { adc(cpu, cpu.Registers.H) }                  // ADC A,H
-	instructions[0x8d] = func() { adc(cpu, cpu.Registers.L) }                  // ADC A,L
-	instructions[0x8e] = func() { adc(cpu, cpu.MMU.Read(cpu.Registers.HL())) } // ADC A,(HL)
-	instructions[0x8f] = func() { adc(cpu, cpu.Registers.A) }                  // ADC A,A
-
-	instructions[0x90] = func() { sub(cpu, cpu.Registers.B) }                  // SUB A,B
-	instructions[0x91] = func() { sub(cpu, cpu.Registers.C) }                  // SUB A,C
-	instructions[0x92] = func() { sub(cpu, cpu.Registers.D) }                  // SUB A,D
-	instructions[0x93] = func() { sub(cpu, cpu.Registers.E) }                  // SUB A,E
-	instructions[0x94] = func() { sub(cpu, cpu.Registers.H) }                  // SUB A,H
-	instructions[0x95] = func() { sub(cpu, cpu.Registers.L) }                  // SUB A,L
-	instructions[0x96] = func() { sub(cpu, cpu.MMU.Read(cpu.Registers.HL())) } // SUB A,(HL)
-	instructions[0x97] = func() { sub(cpu, cpu.Registers.A) }                  // SUB A,A
-	instructions[0x98] = func() { sbc(cpu, cpu.Registers.B) }                  // SBC A,B
-	instructions[0x99] = func() { sbc(cpu, cpu.Registers.C) }                  // SBC A,C
-	instructions[0x9a] = func() { sbc(cpu, cpu.Registers.D) }                  // SBC A,D
-	instructions[0x9b] = func() { sbc(cpu, cpu.Registers.E) }                  // SBC A,E
-	instructions[0x9c] = func() { sbc(cpu, cpu.Registers.H) }                  // SBC A,H
-	instructions[0x9d] = func() { sbc(cpu, cpu.Registers.L) }                  // SBC A,L
-	instructions[0x9e] = func() { sbc(cpu, cpu.MMU.Read(cpu.Registers.HL())) } // SBC A,(HL)
-	instructions[0x9f] = func() { sbc(cpu, cpu.Registers.A) }                  // SBC A,A
-
-	instructions[0xa0] = func() { and(cpu, cpu.Registers.B) }                  // AND B
-	instructions[0xa1] = func() { and(cpu, cpu.Registers.C) }                  // AND C
-	instructions[0xa2] = func() { and(cpu, cpu.Registers.D) }                  // AND D
-	instructions[0xa3] = func() { and(cpu, cpu.Registers.E) }                  // AND E
-	instructions[0xa4] = func() { and(cpu, cpu.Registers.H) }                  // AND H
-	instructions[0xa5] = func() { and(cpu, cpu.Registers.L) }                  // AND L
-	instructions[0xa6] = func() { and(cpu, cpu.MMU.Read(cpu.Registers.HL())) } // AND (HL)
-	instructions[0xa7] = func() { and(cpu, cpu.Registers.A) }                  // AND A
-	instructions[0xa8] = func() { xor(cpu, cpu.Registers.B) }                  // XOR B
-	instructions[0xa9] = func() { xor(cpu, cpu.Registers.C) }                  // XOR C
-	instructions[0xaa] = func() { xor(cpu, cpu.Registers.D) }                  // XOR D
-	instructions[0xab] = func() { xor(cpu, cpu.Registers.E) }                  // XOR E
-	instructions[0xac] = func() { xor(cpu, cpu.Registers.H) }                  // XOR H
-	instructions[0xad] = func() { xor(cpu, cpu.Registers.L) }                  // XOR L
-	instructions[0xae] = func() { xor(cpu, cpu.MMU.Read(cpu.Registers.HL())) } // XOR (HL)
-	instructions[0xaf] = func() { xor(cpu, cpu.Registers.A) }                  // XOR A
-
-	instructions[0xb0] = func() { or(cpu, cpu.Registers.B) }                  // OR B
-	instructions[0xb1] = func() { or(cpu, cpu.Registers.C) }                  // OR C
-	instructions[0xb2] = func() { or(cpu, cpu.Registers.D) }                  // OR D
-	instructions[0xb3] = func() { or(cpu, cpu.Registers.E) }                  // OR E
-	instructions[0xb4] = func() { or(cpu, cpu.Registers.H) }                  // OR H
-	instructions[0xb5] = func() { or(cpu, cpu.Registers.L) }                  // OR L
-	instructions[0xb6] = func() { or(cpu, cpu.MMU.Read(cpu.Registers.HL())) } // OR (HL)
-	instructions[0xb7] = func() { or(cpu, cpu.Registers.A) }                  // OR A
-	instructions[0xb8] = func() { cp(cpu, cpu.Registers.B) }                  // CP B
-	instructions[0xb9] = func() { cp(cpu, cpu.Registers.C) }                  // CP C
-	instructions[0xba] = func() { cp(cpu, cpu.Registers.D) }                  // CP D
-	instructions[0xbb] = func() { cp(cpu, cpu.Registers.E) }                  // CP E
-	instructions[0xbc] = func() { cp(cpu, cpu.Registers.H) }                  // CP H
-	instructions[0xbd] = func() { cp(cpu, cpu.Registers.L) }                  // CP L
-	instructions[0xbe] = func() { cp(cpu, cpu.MMU.Read(cpu.Registers.HL())) } // CP (HL)
-	instructions[0xbf] = func() { cp(cpu, cpu.Registers.A) }                  // CP A
-
-	instructions[0xc0] = func() { retCC(cpu, !cpu.Zero()) }                 // RET NZ
-	instructions[0xc1] = func() { cpu.Registers.SetBC(popNN(cpu)) }         // POP BC
-	instructions[0xc2] = func() { jpCC(cpu, !cpu.Zero(), cpu.Fetch16()) }   // JP NZ,nn
-	instructions[0xc3] = func() { cpu.PC = cpu.Fetch16() }                  // JP nn
-	instructions[0xc4] = func() { callCC(cpu, !cpu.Zero(), cpu.Fetch16()) } // CALL NZ,nn
-	instructions[0xc5] = func() { pushNN(cpu, cpu.Registers.BC()) }         // PUSH BC
-	instructions[0xc6] = func() { add(cpu, cpu.Fetch()) }                   // ADD A,#
-	instructions[0xc7] = func() { rst(cpu, 0x00) }                          // RST 00H
-	instructions[0xc8] = func() { retCC(cpu, cpu.Zero()) }                  // RET Z
-	instructions[0xc9] = func() { cpu.PC = popNN(cpu) }                     // RET
-	instructions[0xca] = func() { jpCC(cpu, cpu.Zero(), cpu.Fetch16()) }    // JP Z,nn
-	instructions[0xcb] = nop                                                // Extended instructions
-	instructions[0xcc] = func() { callCC(cpu, cpu.Zero(), cpu.Fetch16()) }  // CALL Z,nn
-	instructions[0xcd] = func() { call(cpu, cpu.Fetch16()) }                // CALL nn
-	instructions[0xce] = func() { adc(cpu, cpu.Fetch()) }                   // ADC A,#
-	instructions[0xcf] = func() { rst(cpu, 0x08) }                          // RST 08H
-
-	instructions[0xd0] = func() { retCC(cpu, !cpu.Carry()) }                 // RET NC
-	instructions[0xd1] = func() { cpu.Registers.SetDE(popNN(cpu)) }          // POP DE
-	instructions[0xd2] = func() { jpCC(cpu, !cpu.Carry(), cpu.Fetch16()) }   // JP NC,nn
-	instructions[0xd3] = xx                                                  // XX
-	instructions[0xd4] = func() { callCC(cpu, !cpu.Carry(), cpu.Fetch16()) } // CALL NC,nn
-	instructions[0xd5] = func() { pushNN(cpu, cpu.Registers.DE()) }          // PUSH DE
-	instructions[0xd6] = func() { sub(cpu, cpu.Fetch()) }                    // SUB A,#
-	instructions[0xd7] = func() { rst(cpu, 0x10) }                           // RST 10H
-	instructions[0xd8] = func() { retCC(cpu, cpu.Carry()) }                  // RET C
-	instructions[0xd9] = func() { reti(cpu) }                                // RETI
-	instructions[0xda] = func() { jpCC(cpu, cpu.Carry(), cpu.Fetch16()) }    // JP C,nn
-	instructions[0xdb] = xx                                                  // XX
-	instructions[0xdc] = func() { callCC(cpu, cpu.Carry(), cpu.Fetch16()) }  // CALL C,nn
-	instructions[0xdd] = xx                                                  // XX
-	instructions[0xde] = func() { sbc(cpu, cpu.Fetch()) }                    // SBC A,#
-	instructions[0xdf] = func() { rst(cpu, 0x18) }                           // RST 18H
-
-	instructions[0xe0] = func() { cpu.MMU.Write(0xff00+uint16(cpu.Fetch()), cpu.Registers.A) }     // LDH (n),A
-	instructions[0xe1] = func() { cpu.Registers.SetHL(popNN(cpu)) }                                // POP HL
-	instructions[0xe2] = func() { cpu.MMU.Write(0xff00+uint16(cpu.Registers.C), cpu.Registers.A) } // LD (C),A
-	instructions[0xe3] = xx                                                                        // XX
-	instructions[0xe4] = xx                                                                        // XX
-	instructions[0xe5] = func() { pushNN(cpu, cpu.Registers.HL()) }                                // PUSH HL
-	instructions[0xe6] = func() { and(cpu, cpu.Fetch()) }                                          // AND #
-	instructions[0xe7] = func() { rst(cpu, 0x20) }                                                 // RST 20H
-	instructions[0xe8] = func() { addSP(cpu, int8(cpu.Fetch())) }                                  // ADD SP,n
-	instructions[0xe9] = func() { cpu.PC = cpu.Registers.HL() }                                    // JP (HL)
-	instructions[0xea] = func() { cpu.MMU.Write(cpu.Fetch16(), cpu.Registers.A) }                  // LD (nn),A
-	instructions[0xeb] = xx                                                                        // XX
-	instructions[0xec] = xx                                                                        // XX
-	instructions[0xed] = xx                                                                        // XX
-	instructions[0xee] = func() { xor(cpu, cpu.Fetch()) }                                          // XOR #
-	instructions[0xef] = func() { rst(cpu, 0x28) }                                                 // RST 28H
-
-	instructions[0xf0] = func() { cpu.Registers.A = cpu.MMU.Read(0xff00 + uint16(cpu.Fetch())) }     // LDH A,(n)
-	instructions[0xf1] = func() { cpu.Registers.SetAF(popNN(cpu)) }                                  // POP AF
-	instructions[0xf2] = func() { cpu.Registers.A = cpu.MMU.Read(0xff00 + uint16(cpu.Registers.C)) } // LD A,(C)
-	instructions[0xf3] = func() { cpu.disablingInterrupts = true }                                   // DI
-	instructions[0xf4] = xx                                                                          // XX
-	instructions[0xf5] = func() { pushNN(cpu, cpu.Registers.AF()) }                                  // PUSH AF
-	instructions[0xf6] = func() { or(cpu, cpu.Fetch()) }                                             // OR #
-	instructions[0xf7] = func() { rst(cpu, 0x30) }                                                   // RST 30H
-	instructions[0xf8] = func() { ldHLSPPlusN(cpu, int8(cpu.Fetch())) }                              // LD HL,SP+n
-	instructions[0xf9] = func() { cpu.SP = cpu.Registers.HL() }                                      // LD SP,HL
-	instructions[0xfa] = func() { cpu.Registers.A = cpu.MMU.Read(cpu.Fetch16()) }                    // LD A,(nn)
-	instructions[0xfb] = func() { cpu.enablingInterrupts = true }                                    // EI
-	instructions[0xfc] = xx                                                                          // XX
-	instructions[0xfd] = xx                                                                          // XX
-	instructions[0xfe] = func() { cp(cpu, cpu.Fetch()) }                                             // CP #
-	instructions[0xff] = func() { rst(cpu, 0x38) }                                                   // RST 38H
+func initInstructionList() {
+	instructions[0x00] = nop                                                                  // NOP
+	instructions[0x01] = func(cpu *CPU) { cpu.Registers.SetBC(cpu.Fetch16()) }                // LD BC,nn
+	instructions[0x02] = func(cpu *CPU) { cpu.write(cpu.Registers.BC(), cpu.Registers.A) }    // LD (BC),A
+	instructions[0x03] = func(cpu *CPU) { incNN(cpu, cpu.Registers.BC, cpu.Registers.SetBC) } // INC BC
+	instructions[0x04] = func(cpu *CPU) { incN(cpu, &cpu.Registers.B) }                       // INC B
+	instructions[0x05] = func(cpu *CPU) { decN(cpu, &cpu.Registers.B) }                       // DEC B
+	instructions[0x06] = func(cpu *CPU) { cpu.Registers.B = cpu.Fetch() }                     // LD B,n
+	instructions[0x07] = func(cpu *CPU) { rlca(cpu) }                                         // RLCA
+	instructions[0x08] = func(cpu *CPU) { ldNNSP(cpu) }                                       // LD (nn),SP
+	instructions[0x09] = func(cpu *CPU) { addHL(cpu, cpu.Registers.BC()) }                    // ADD HL,BC
+	instructions[0x0a] = func(cpu *CPU) { cpu.Registers.A = cpu.read(cpu.Registers.BC()) }    // LD A,(BC)
+	instructions[0x0b] = func(cpu *CPU) { decNN(cpu, cpu.Registers.BC, cpu.Registers.SetBC) } // DEC BC
+	instructions[0x0c] = func(cpu *CPU) { incN(cpu, &cpu.Registers.C) }                       // INC C
+	instructions[0x0d] = func(cpu *CPU) { decN(cpu, &cpu.Registers.C) }                       // DEC C
+	instructions[0x0e] = func(cpu *CPU) { cpu.Registers.C = cpu.Fetch() }                     // LD C,n
+	instructions[0x0f] = func(cpu *CPU) { rrca(cpu) }                                         // RRCA
+
+	instructions[0x10] = func(cpu *CPU) { stop(cpu) }                                         // STOP
+	instructions[0x11] = func(cpu *CPU) { cpu.Registers.SetDE(cpu.Fetch16()) }                // LD DE,nn
+	instructions[0x12] = func(cpu *CPU) { cpu.write(cpu.Registers.DE(), cpu.Registers.A) }    // LD (DE),A
+	instructions[0x13] = func(cpu *CPU) { incNN(cpu, cpu.Registers.DE, cpu.Registers.SetDE) } // INC DE
+	instructions[0x14] = func(cpu *CPU) { incN(cpu, &cpu.Registers.D) }                       // INC D
+	instructions[0x15] = func(cpu *CPU) { decN(cpu, &cpu.Registers.D) }                       // DEC D
+	instructions[0x16] = func(cpu *CPU) { cpu.Registers.D = cpu.Fetch() }                     // LD D,n
+	instructions[0x17] = func(cpu *CPU) { rla(cpu) }                                          // RLA
+	instructions[0x18] = func(cpu *CPU) { jr(cpu, int8(cpu.Fetch())) }                        // JR n
+	instructions[0x19] = func(cpu *CPU) { addHL(cpu, cpu.Registers.DE()) }                    // ADD HL,DE
+	instructions[0x1a] = func(cpu *CPU) { cpu.Registers.A = cpu.read(cpu.Registers.DE()) }    // LD A,(DE)
+	instructions[0x1b] = func(cpu *CPU) { decNN(cpu, cpu.Registers.DE, cpu.Registers.SetDE) } // DEC DE
+	instructions[0x1c] = func(cpu *CPU) { incN(cpu, &cpu.Registers.E) }                       // INC E
+	instructions[0x1d] = func(cpu *CPU) { decN(cpu, &cpu.Registers.E) }                       // DEC E
+	instructions[0x1e] = func(cpu *CPU) { cpu.Registers.E = cpu.Fetch() }                     // LD E,n
+	instructions[0x1f] = func(cpu *CPU) { rra(cpu) }                                          // RRA
+
+	instructions[0x20] = func(cpu *CPU) { jrCC(cpu, !cpu.Zero(), int8(cpu.Fetch())) }         // JP NZ,*
+	instructions[0x21] = func(cpu *CPU) { cpu.Registers.SetHL(cpu.Fetch16()) }                // LD HL,nn
+	instructions[0x22] = func(cpu *CPU) { ldiHLA(cpu) }                                       // LDI (HL),A
+	instructions[0x23] = func(cpu *CPU) { incNN(cpu, cpu.Registers.HL, cpu.Registers.SetHL) } // INC HL
+	instructions[0x24] = func(cpu *CPU) { incN(cpu, &cpu.Registers.H) }                       // INC H
+	instructions[0x25] = func(cpu *CPU) { decN(cpu, &cpu.Registers.H) }                       // DEC H
+	instructions[0x26] = func(cpu *CPU) { cpu.Registers.H = cpu.Fetch() }                     // LD H,n
+	instructions[0x27] = func(cpu *CPU) { daa(cpu) }                                          // DAA
+	instructions[0x28] = func(cpu *CPU) { jrCC(cpu, cpu.Zero(), int8(cpu.Fetch())) }          // JP Z,*
+	instructions[0x29] = func(cpu *CPU) { addHL(cpu, cpu.Registers.HL()) }                    // ADD HL,HL
+	instructions[0x2a] = func(cpu *CPU) { ldiAHL(cpu) }                                       // LDI A,(HL)
+	instructions[0x2b] = func(cpu *CPU) { decNN(cpu, cpu.Registers.HL, cpu.Registers.SetHL) } // DEC HL
+	instructions[0x2c] = func(cpu *CPU) { incN(cpu, &cpu.Registers.L) }                       // INC L
+	instructions[0x2d] = func(cpu *CPU) { decN(cpu, &cpu.Registers.L) }                       // DEC L
+	instructions[0x2e] = func(cpu *CPU) { cpu.Registers.L = cpu.Fetch() }                     // LD L,n
+	instructions[0x2f] = func(cpu *CPU) { cpl(cpu) }                                          // CPL
+
+	instructions[0x30] = func(cpu *CPU) { jrCC(cpu, !cpu.Carry(), int8(cpu.Fetch())) } // JP NC,*
+	instructions[0x31] = func(cpu *CPU) { cpu.SP = cpu.Fetch16() }                     // LD SP,nn
+	instructions[0x32] = func(cpu *CPU) { lddHLA(cpu) }                                // LDD (HL),A
+	instructions[0x33] = func(cpu *CPU) { cpu.SP++ }                                   // INC SP
+	instructions[0x34] = func(cpu *CPU) { incHL(cpu) }                                 // INC (HL)
+	instructions[0x35] = func(cpu *CPU) { decHL(cpu) }                                 // DEC (HL)
+	instructions[0x36] = func(cpu *CPU) { cpu.write(cpu.Registers.HL(), cpu.Fetch()) } // LD (HL),n
+	instructions[0x37] = func(cpu *CPU) { scf(cpu) }                                   // SCF
+	instructions[0x38] = func(cpu *CPU) { jrCC(cpu, cpu.Carry(), int8(cpu.Fetch())) }  // JP C,*
+	instructions[0x39] = func(cpu *CPU) { addHL(cpu, cpu.SP) }                         // ADD HL,SP
+	instructions[0x3a] = func(cpu *CPU) { lddAHL(cpu) }                                // LDD A,(HL)
+	instructions[0x3b] = func(cpu *CPU) { cpu.SP-- }                                   // DEC SP
+	instructions[0x3c] = func(cpu *CPU) { incN(cpu, &cpu.Registers.A) }                // INC A
+	instructions[0x3d] = func(cpu *CPU) { decN(cpu, &cpu.Registers.A) }                // DEC A
+	instructions[0x3e] = func(cpu *CPU) { cpu.Registers.A = cpu.Fetch() }              // LD A,#
+	instructions[0x3f] = func(cpu *CPU) { ccf(cpu) }                                   // CCF
+
+	instructions[0x40] = func(cpu *CPU) {}                                                 // LD B,B
+	instructions[0x41] = func(cpu *CPU) { cpu.Registers.B = cpu.Registers.C }              // LD B,C
+	instructions[0x42] = func(cpu *CPU) { cpu.Registers.B = cpu.Registers.D }              // LD B,D
+	instructions[0x43] = func(cpu *CPU) { cpu.Registers.B = cpu.Registers.E }              // LD B,E
+	instructions[0x44] = func(cpu *CPU) { cpu.Registers.B = cpu.Registers.H }              // LD B,H
+	instructions[0x45] = func(cpu *CPU) { cpu.Registers.B = cpu.Registers.L }              // LD B,L
+	instructions[0x46] = func(cpu *CPU) { cpu.Registers.B = cpu.read(cpu.Registers.HL()) } // LD B,(HL)
+	instructions[0x47] = func(cpu *CPU) { cpu.Registers.B = cpu.Registers.A }              // LD B,A
+	instructions[0x48] = func(cpu *CPU) { cpu.Registers.C = cpu.Registers.B }              // LD C,B
+	instructions[0x49] = func(cpu *CPU) {}                                                 // LD C,C
+	instructions[0x4a] = func(cpu *CPU) { cpu.Registers.C = cpu.Registers.D }              // LD C,D
+	instructions[0x4b] = func(cpu *CPU) { cpu.Registers.C = cpu.Registers.E }              // LD C,E
+	instructions[0x4c] = func(cpu *CPU) { cpu.Registers.C = cpu.Registers.H }              // LD C,H
+	instructions[0x4d] = func(cpu *CPU) { cpu.Registers.C = cpu.Registers.L }              // LD C,L
+	instructions[0x4e] = func(cpu *CPU) { cpu.Registers.C = cpu.read(cpu.Registers.HL()) } // LD C,(HL)
+	instructions[0x4f] = func(cpu *CPU) { cpu.Registers.C = cpu.Registers.A }              // LD C,A
+
+	instructions[0x50] = func(cpu *CPU) { cpu.Registers.D = cpu.Registers.B }              // LD D,B
+	instructions[0x51] = func(cpu *CPU) { cpu.Registers.D = cpu.Registers.C }              // LD D,C
+	instructions[0x52] = func(cpu *CPU) {}                                                 // LD D,D
+	instructions[0x53] = func(cpu *CPU) { cpu.Registers.D = cpu.Registers.E }              // LD D,E
+	instructions[0x54] = func(cpu *CPU) { cpu.Registers.D = cpu.Registers.H }              // LD D,H
+	instructions[0x55] = func(cpu *CPU) { cpu.Registers.D = cpu.Registers.L }              // LD D,L
+	instructions[0x56] = func(cpu *CPU) { cpu.Registers.D = cpu.read(cpu.Registers.HL()) } // LD D,(HL)
+	instructions[0x57] = func(cpu *CPU) { cpu.Registers.D = cpu.Registers.A }              // LD D,A
+	instructions[0x58] = func(cpu *CPU) { cpu.Registers.E = cpu.Registers.B }              // LD E,B
+	instructions[0x59] = func(cpu *CPU) { cpu.Registers.E = cpu.Registers.C }              // LD E,C
+	instructions[0x5a] = func(cpu *CPU) { cpu.Registers.E = cpu.Registers.D }              // LD E,D
+	instructions[0x5b] = func(cpu *CPU) {}                                                 // LD E,E
+	instructions[0x5c] = func(cpu *CPU) { cpu.Registers.E = cpu.Registers.H }              // LD E,H
+	instructions[0x5d] = func(cpu *CPU) { cpu.Registers.E = cpu.Registers.L }              // LD E,L
+	instructions[0x5e] = func(cpu *CPU) { cpu.Registers.E = cpu.read(cpu.Registers.HL()) } // LD E,(HL)
+	instructions[0x5f] = func(cpu *CPU) { cpu.Registers.E = cpu.Registers.A }              // LD E,A
+
+	instructions[0x60] = func(cpu *CPU) { cpu.Registers.H = cpu.Registers.B }              // LD H,B
+	instructions[0x61] = func(cpu *CPU) { cpu.Registers.H = cpu.Registers.C }              // LD H,C
+	instructions[0x62] = func(cpu *CPU) { cpu.Registers.H = cpu.Registers.D }              // LD H,D
+	instructions[0x63] = func(cpu *CPU) { cpu.Registers.H = cpu.Registers.E }              // LD H,E
+	instructions[0x64] = func(cpu *CPU) {}                                                 // LD H,H
+	instructions[0x65] = func(cpu *CPU) { cpu.Registers.H = cpu.Registers.L }              // LD H,L
+	instructions[0x66] = func(cpu *CPU) { cpu.Registers.H = cpu.read(cpu.Registers.HL()) } // LD H,(HL)
+	instructions[0x67] = func(cpu *CPU) { cpu.Registers.H = cpu.Registers.A }              // LD H,A
+	instructions[0x68] = func(cpu *CPU) { cpu.Registers.L = cpu.Registers.B }              // LD L,B
+	instructions[0x69] = func(cpu *CPU) { cpu.Registers.L = cpu.Registers.C }              // LD L,C
+	instructions[0x6a] = func(cpu *CPU) { cpu.Registers.L = cpu.Registers.D }              // LD L,D
+	instructions[0x6b] = func(cpu *CPU) { cpu.Registers.L = cpu.Registers.E }              // LD L,E
+	instructions[0x6c] = func(cpu *CPU) { cpu.Registers.L = cpu.Registers.H }              // LD L,H
+	instructions[0x6d] = func(cpu *CPU) {}                                                 // LD L,L
+	instructions[0x6e] = func(cpu *CPU) { cpu.Registers.L = cpu.read(cpu.Registers.HL()) } // LD L,(HL)
+	instructions[0x6f] = func(cpu *CPU) { cpu.Registers.L = cpu.Registers.A }              // LD L,A
+
+	instructions[0x70] = func(cpu *CPU) { cpu.write(cpu.Registers.HL(), cpu.Registers.B) } // LD (HL),B
+	instructions[0x71] = func(cpu *CPU) { cpu.write(cpu.Registers.HL(), cpu.Registers.C) } // LD (HL),C
+	instructions[0x72] = func(cpu *CPU) { cpu.write(cpu.Registers.HL(), cpu.Registers.D) } // LD (HL),D
+	instructions[0x73] = func(cpu *CPU) { cpu.write(cpu.Registers.HL(), cpu.Registers.E) } // LD (HL),E
+	instructions[0x74] = func(cpu *CPU) { cpu.write(cpu.Registers.HL(), cpu.Registers.H) } // LD (HL),H
+	instructions[0x75] = func(cpu *CPU) { cpu.write(cpu.Registers.HL(), cpu.Registers.L) } // LD (HL),L
+	instructions[0x76] = func(cpu *CPU) { halt(cpu) }                                      // HALT
+	instructions[0x77] = func(cpu *CPU) { cpu.write(cpu.Registers.HL(), cpu.Registers.A) } // LD (HL),A
+	instructions[0x78] = func(cpu *CPU) { cpu.Registers.A = cpu.Registers.B }              // LD A,B
+	instructions[0x79] = func(cpu *CPU) { cpu.Registers.A = cpu.Registers.C }              // LD A,C
+	instructions[0x7a] = func(cpu *CPU) { cpu.Registers.A = cpu.Registers.D }              // LD A,D
+	instructions[0x7b] = func(cpu *CPU) { cpu.Registers.A = cpu.Registers.E }              // LD A,E
+	instructions[0x7c] = func(cpu *CPU) { cpu.Registers.A = cpu.Registers.H }              // LD A,H
+	instructions[0x7d] = func(cpu *CPU) { cpu.Registers.A = cpu.Registers.L }              // LD A,L
+	instructions[0x7e] = func(cpu *CPU) { cpu.Registers.A = cpu.read(cpu.Registers.HL()) } // LD A,(HL)
+	instructions[0x7f] = func(cpu *CPU) {}                                                 // LD A,A
+
+	instructions[0x80] = func(cpu *CPU) { add(cpu, cpu.Registers.B) }              // ADD A,B
+	instructions[0x81] = func(cpu *CPU) { add(cpu, cpu.Registers.C) }              // ADD A,C
+	instructions[0x82] = func(cpu *CPU) { add(cpu, cpu.Registers.D) }              // ADD A,D
+	instructions[0x83] = func(cpu *CPU) { add(cpu, cpu.Registers.E) }              // ADD A,E
+	instructions[0x84] = func(cpu *CPU) { add(cpu, cpu.Registers.H) }              // ADD A,H
+	instructions[0x85] = func(cpu *CPU) { add(cpu, cpu.Registers.L) }              // ADD A,L
+	instructions[0x86] = func(cpu *CPU) { add(cpu, cpu.read(cpu.Registers.HL())) } // ADD A,(HL)
+	instructions[0x87] = func(cpu *CPU) { add(cpu, cpu.Registers.A) }              // ADD A,A
+	instructions[0x88] = func(cpu *CPU) { adc(cpu, cpu.Registers.B) }              // ADC A,B
+	instructions[0x89] = func(cpu *CPU) { adc(cpu, cpu.Registers.C) }              // ADC A,C
+	instructions[0x8a] = func(cpu *CPU) { adc(cpu, cpu.Registers.D) }              // ADC A,D
+	instructions[0x8b] = func(cpu *CPU) { adc(cpu, cpu.Registers.E) }              // ADC A,E
+	instructions[0x8c] = func(cpu *CPU) { adc(cpu, cpu.Registers.H) }              // ADC A,H
+	instructions[0x8d] = func(cpu *CPU) { adc(cpu, cpu.Registers.L) }              // ADC A,L
+	instructions[0x8e] = func(cpu *CPU) { adc(cpu, cpu.read(cpu.Registers.HL())) } // ADC A,(HL)
+	instructions[0x8f] = func(cpu *CPU) { adc(cpu, cpu.Registers.A) }              // ADC A,A
+
+	instructions[0x90] = func(cpu *CPU) { sub(cpu, cpu.Registers.B) }              // SUB A,B
+	instructions[0x91] = func(cpu *CPU) { sub(cpu, cpu.Registers.C) }              // SUB A,C
+	instructions[0x92] = func(cpu *CPU) { sub(cpu, cpu.Registers.D) }              // SUB A,D
+	instructions[0x93] = func(cpu *CPU) { sub(cpu, cpu.Registers.E) }              // SUB A,E
+	instructions[0x94] = func(cpu *CPU) { sub(cpu, cpu.Registers.H) }              // SUB A,H
+	instructions[0x95] = func(cpu *CPU) { sub(cpu, cpu.Registers.L) }              // SUB A,L
+	instructions[0x96] = func(cpu *CPU) { sub(cpu, cpu.read(cpu.Registers.HL())) } // SUB A,(HL)
+	instructions[0x97] = func(cpu *CPU) { sub(cpu, cpu.Registers.A) }              // SUB A,A
+	instructions[0x98] = func(cpu *CPU) { sbc(cpu, cpu.Registers.B) }              // SBC A,B
+	instructions[0x99] = func(cpu *CPU) { sbc(cpu, cpu.Registers.C) }              // SBC A,C
+	instructions[0x9a] = func(cpu *CPU) { sbc(cpu, cpu.Registers.D) }              // SBC A,D
+	instructions[0x9b] = func(cpu *CPU) { sbc(cpu, cpu.Registers.E) }              // SBC A,E
+	instructions[0x9c] = func(cpu *CPU) { sbc(cpu, cpu.Registers.H) }              // SBC A,H
+	instructions[0x9d] = func(cpu *CPU) { sbc(cpu, cpu.Registers.L) }              // SBC A,L
+	instructions[0x9e] = func(cpu *CPU) { sbc(cpu, cpu.read(cpu.Registers.HL())) } // SBC A,(HL)
+	instructions[0x9f] = func(cpu *CPU) { sbc(cpu, cpu.Registers.A) }              // SBC A,A
+
+	instructions[0xa0] = func(cpu *CPU) { and(cpu, cpu.Registers.B) }              // AND B
+	instructions[0xa1] = func(cpu *CPU) { and(cpu, cpu.Registers.C) }              // AND C
+	instructions[0xa2] = func(cpu *CPU) { and(cpu, cpu.Registers.D) }              // AND D
+	instructions[0xa3] = func(cpu *CPU) { and(cpu, cpu.Registers.E) }              // AND E
+	instructions[0xa4] = func(cpu *CPU) { and(cpu, cpu.Registers.H) }              // AND H
+	instructions[0xa5] = func(cpu *CPU) { and(cpu, cpu.Registers.L) }              // AND L
+	instructions[0xa6] = func(cpu *CPU) { and(cpu, cpu.read(cpu.Registers.HL())) } // AND (HL)
+	instructions[0xa7] = func(cpu *CPU) { and(cpu, cpu.Registers.A) }              // AND A
+	instructions[0xa8] = func(cpu *CPU) { xor(cpu, cpu.Registers.B) }              // XOR B
+	instructions[0xa9] = func(cpu *CPU) { xor(cpu, cpu.Registers.C) }              // XOR C
+	instructions[0xaa] = func(cpu *CPU) { xor(cpu, cpu.Registers.D) }              // XOR D
+	instructions[0xab] = func(cpu *CPU) { xor(cpu, cpu.Registers.E) }              // XOR E
+	instructions[0xac] = func(cpu *CPU) { xor(cpu, cpu.Registers.H) }              // XOR H
+	instructions[0xad] = func(cpu *CPU) { xor(cpu, cpu.Registers.L) }              // XOR L
+	instructions[0xae] = func(cpu *CPU) { xor(cpu, cpu.read(cpu.Registers.HL())) } // XOR (HL)
+	instructions[0xaf] = func(cpu *CPU) { xor(cpu, cpu.Registers.A) }              // XOR A
+
+	instructions[0xb0] = func(cpu *CPU) { or(cpu, cpu.Registers.B) }              // OR B
+	instructions[0xb1] = func(cpu *CPU) { or(cpu, cpu.Registers.C) }              // OR C
+	instructions[0xb2] = func(cpu *CPU) { or(cpu, cpu.Registers.D) }              // OR D
+	instructions[0xb3] = func(cpu *CPU) { or(cpu, cpu.Registers.E) }              // OR E
+	instructions[0xb4] = func(cpu *CPU) { or(cpu, cpu.Registers.H) }              // OR H
+	instructions[0xb5] = func(cpu *CPU) { or(cpu, cpu.Registers.L) }              // OR L
+	instructions[0xb6] = func(cpu *CPU) { or(cpu, cpu.read(cpu.Registers.HL())) } // OR (HL)
+	instructions[0xb7] = func(cpu *CPU) { or(cpu, cpu.Registers.A) }              // OR A
+	instructions[0xb8] = func(cpu *CPU) { cp(cpu, cpu.Registers.B) }              // CP B
+	instructions[0xb9] = func(cpu *CPU) { cp(cpu, cpu.Registers.C) }              // CP C
+	instructions[0xba] = func(cpu *CPU) { cp(cpu, cpu.Registers.D) }              // CP D
+	instructions[0xbb] = func(cpu *CPU) { cp(cpu, cpu.Registers.E) }              // CP E
+	instructions[0xbc] = func(cpu *CPU) { cp(cpu, cpu.Registers.H) }              // CP H
+	instructions[0xbd] = func(cpu *CPU) { cp(cpu, cpu.Registers.L) }              // CP L
+	instructions[0xbe] = func(cpu *CPU) { cp(cpu, cpu.read(cpu.Registers.HL())) } // CP (HL)
+	instructions[0xbf] = func(cpu *CPU) { cp(cpu, cpu.Registers.A) }              // CP A
+
+	instructions[0xc0] = func(cpu *CPU) { retCC(cpu, !cpu.Zero()) }                 // RET NZ
+	instructions[0xc1] = func(cpu *CPU) { cpu.Registers.SetBC(popNN(cpu)) }         // POP BC
+	instructions[0xc2] = func(cpu *CPU) { jpCC(cpu, !cpu.Zero(), cpu.Fetch16()) }   // JP NZ,nn
+	instructions[0xc3] = func(cpu *CPU) { cpu.PC = cpu.Fetch16() }                  // JP nn
+	instructions[0xc4] = func(cpu *CPU) { callCC(cpu, !cpu.Zero(), cpu.Fetch16()) } // CALL NZ,nn
+	instructions[0xc5] = func(cpu *CPU) { pushNN(cpu, cpu.Registers.BC()) }         // PUSH BC
+	instructions[0xc6] = func(cpu *CPU) { add(cpu, cpu.Fetch()) }                   // ADD A,#
+	instructions[0xc7] = func(cpu *CPU) { rst(cpu, 0x00) }                          // RST 00H
+	instructions[0xc8] = func(cpu *CPU) { retCC(cpu, cpu.Zero()) }                  // RET Z
+	instructions[0xc9] = func(cpu *CPU) { cpu.PC = popNN(cpu) }                     // RET
+	instructions[0xca] = func(cpu *CPU) { jpCC(cpu, cpu.Zero(), cpu.Fetch16()) }    // JP Z,nn
+	instructions[0xcb] = nop                                                        // Extended instructions
+	instructions[0xcc] = func(cpu *CPU) { callCC(cpu, cpu.Zero(), cpu.Fetch16()) }  // CALL Z,nn
+	instructions[0xcd] = func(cpu *CPU) { call(cpu, cpu.Fetch16()) }                // CALL nn
+	instructions[0xce] = func(cpu *CPU) { adc(cpu, cpu.Fetch()) }                   // ADC A,#
+	instructions[0xcf] = func(cpu *CPU) { rst(cpu, 0x08) }                          // RST 08H
+
+	instructions[0xd0] = func(cpu *CPU) { retCC(cpu, !cpu.Carry()) }                 // RET NC
+	instructions[0xd1] = func(cpu *CPU) { cpu.Registers.SetDE(popNN(cpu)) }          // POP DE
+	instructions[0xd2] = func(cpu *CPU) { jpCC(cpu, !cpu.Carry(), cpu.Fetch16()) }   // JP NC,nn
+	instructions[0xd3] = func(cpu *CPU) { xx(cpu, 0xd3) }                            // XX
+	instructions[0xd4] = func(cpu *CPU) { callCC(cpu, !cpu.Carry(), cpu.Fetch16()) } // CALL NC,nn
+	instructions[0xd5] = func(cpu *CPU) { pushNN(cpu, cpu.Registers.DE()) }          // PUSH DE
+	instructions[0xd6] = func(cpu *CPU) { sub(cpu, cpu.Fetch()) }                    // SUB A,#
+	instructions[0xd7] = func(cpu *CPU) { rst(cpu, 0x10) }                           // RST 10H
+	instructions[0xd8] = func(cpu *CPU) { retCC(cpu, cpu.Carry()) }                  // RET C
+	instructions[0xd9] = func(cpu *CPU) { reti(cpu) }                                // RETI
+	instructions[0xda] = func(cpu *CPU) { jpCC(cpu, cpu.Carry(), cpu.Fetch16()) }    // JP C,nn
+	instructions[0xdb] = func(cpu *CPU) { xx(cpu, 0xdb) }                            // XX
+	instructions[0xdc] = func(cpu *CPU) { callCC(cpu, cpu.Carry(), cpu.Fetch16()) }  // CALL C,nn
+	instructions[0xdd] = func(cpu *CPU) { xx(cpu, 0xdd) }                            // XX
+	instructions[0xde] = func(cpu *CPU) { sbc(cpu, cpu.Fetch()) }                    // SBC A,#
+	instructions[0xdf] = func(cpu *CPU) { rst(cpu, 0x18) }                           // RST 18H
+
+	instructions[0xe0] = func(cpu *CPU) { cpu.write(0xff00+uint16(cpu.Fetch()), cpu.Registers.A) }     // LDH (n),A
+	instructions[0xe1] = func(cpu *CPU) { cpu.Registers.SetHL(popNN(cpu)) }                            // POP HL
+	instructions[0xe2] = func(cpu *CPU) { cpu.write(0xff00+uint16(cpu.Registers.C), cpu.Registers.A) } // LD (C),A
+	instructions[0xe3] = func(cpu *CPU) { xx(cpu, 0xe3) }                                              // XX
+	instructions[0xe4] = func(cpu *CPU) { xx(cpu, 0xe4) }                                              // XX
+	instructions[0xe5] = func(cpu *CPU) { pushNN(cpu, cpu.Registers.HL()) }                            // PUSH HL
+	instructions[0xe6] = func(cpu *CPU) { and(cpu, cpu.Fetch()) }                                      // AND #
+	instructions[0xe7] = func(cpu *CPU) { rst(cpu, 0x20) }                                             // RST 20H
+	instructions[0xe8] = func(cpu *CPU) { addSP(cpu, int8(cpu.Fetch())) }                              // ADD SP,n
+	instructions[0xe9] = func(cpu *CPU) { cpu.PC = cpu.Registers.HL() }                                // JP (HL)
+	instructions[0xea] = func(cpu *CPU) { cpu.write(cpu.Fetch16(), cpu.Registers.A) }                  // LD (nn),A
+	instructions[0xeb] = func(cpu *CPU) { xx(cpu, 0xeb) }                                              // XX
+	instructions[0xec] = func(cpu *CPU) { xx(cpu, 0xec) }                                              // XX
+	instructions[0xed] = func(cpu *CPU) { xx(cpu, 0xed) }                                              // XX
+	instructions[0xee] = func(cpu *CPU) { xor(cpu, cpu.Fetch()) }                                      // XOR #
+	instructions[0xef] = func(cpu *CPU) { rst(cpu, 0x28) }                                             // RST 28H
+
+	instructions[0xf0] = func(cpu *CPU) { cpu.Registers.A = cpu.read(0xff00 + uint16(cpu.Fetch())) }     // LDH A,(n)
+	instructions[0xf1] = func(cpu *CPU) { cpu.Registers.SetAF(popNN(cpu)) }                              // POP AF
+	instructions[0xf2] = func(cpu *CPU) { cpu.Registers.A = cpu.read(0xff00 + uint16(cpu.Registers.C)) } // LD A,(C)
+	instructions[0xf3] = func(cpu *CPU) { di(cpu) }                                                      // DI
+	instructions[0xf4] = func(cpu *CPU) { xx(cpu, 0xf4) }                                                // XX
+	instructions[0xf5] = func(cpu *CPU) { pushNN(cpu, cpu.Registers.AF()) }                              // PUSH AF
+	instructions[0xf6] = func(cpu *CPU) { or(cpu, cpu.Fetch()) }                                         // OR #
+	instructions[0xf7] = func(cpu *CPU) { rst(cpu, 0x30) }                                               // RST 30H
+	instructions[0xf8] = func(cpu *CPU) { ldHLSPPlusN(cpu, int8(cpu.Fetch())) }                          // LD HL,SP+n
+	instructions[0xf9] = func(cpu *CPU) { cpu.SP = cpu.Registers.HL() }                                  // LD SP,HL
+	instructions[0xfa] = func(cpu *CPU) { cpu.Registers.A = cpu.read(cpu.Fetch16()) }                    // LD A,(nn)
+	instructions[0xfb] = func(cpu *CPU) { cpu.enablingInterrupts = true }                                // EI
+	instructions[0xfc] = func(cpu *CPU) { xx(cpu, 0xfc) }                                                // XX
+	instructions[0xfd] = func(cpu *CPU) { xx(cpu, 0xfd) }                                                // XX
+	instructions[0xfe] = func(cpu *CPU) { cp(cpu, cpu.Fetch()) }                                         // CP #
+	instructions[0xff] = func(cpu *CPU) { rst(cpu, 0x38) }                                               // RST 38H
 }
 
 // NOP -- No operation.
-func nop() {}
+func nop(cpu *CPU) {}
 
-// XX -- Operation not supported.
-func xx() {
-	panic("Operation not supported")
+// XX -- Opcode doesn't exist and locks up CPU. CPU doesn't execute anything or respond
+// to interrupts until it is reset.
+func xx(cpu *CPU, opCode byte) {
+	cpu.Locked = true
+	cpu.lockUp = &IllegalOpcodeError{Opcode: opCode, Address: cpu.PC - 1}
 }
 
 /* 8-bit ALU */
@@ -330,11 +347,11 @@ func incN(cpu *CPU, n *byte) {
 // INC (HL) -- Increment value at address HL.
 func incHL(cpu *CPU) {
 	address := cpu.Registers.HL()
-	value := cpu.MMU.Read(address)
+	value := cpu.read(address)
 	halfCarry := (value&0xf)+1 > 0xf
 	result := value + 1
 
-	cpu.MMU.Write(address, result)
+	cpu.write(address, result)
 	cpu.SetNegative(false)
 	cpu.SetZero(result == 0)
 	cpu.SetHalfCarry(halfCarry)
@@ -352,11 +369,11 @@ func decN(cpu *CPU, n *byte) {
 // DEC (HL) -- Decrement value at address HL.
 func decHL(cpu *CPU) {
 	address := cpu.Registers.HL()
-	value := cpu.MMU.Read(address)
+	value := cpu.read(address)
 	halfCarry := value&0x0f == 0
 	result := value - 1
 
-	cpu.MMU.Write(address, result)
+	cpu.write(address, result)
 	cpu.SetZero(result == 0)
 	cpu.SetNegative(true)
 	cpu.SetHalfCarry(halfCarry)
@@ -491,25 +508,25 @@ func addSP(cpu *CPU, n int8) {
 
 // LDD (HL),A -- Put A into memory address HL. Decrement HL.
 func lddHLA(cpu *CPU) {
-	cpu.MMU.Write(cpu.Registers.HL(), cpu.Registers.A)
+	cpu.write(cpu.Registers.HL(), cpu.Registers.A)
 	cpu.Registers.SetHL(cpu.Registers.HL() - 1)
 }
 
 // LDD A,(HL) -- Put value at address HL into A. Decrement HL.
 func lddAHL(cpu *CPU) {
-	cpu.Registers.A = cpu.MMU.Read(cpu.Registers.HL())
+	cpu.Registers.A = cpu.read(cpu.Registers.HL())
 	cpu.Registers.SetHL(cpu.Registers.HL() - 1)
 }
 
 // LDI (HL),A -- Put A into memory address HL. Increment HL.
 func ldiHLA(cpu *CPU) {
-	cpu.MMU.Write(cpu.Registers.HL(), cpu.Registers.A)
+	cpu.write(cpu.Registers.HL(), cpu.Registers.A)
 	cpu.Registers.SetHL(cpu.Registers.HL() + 1)
 }
 
 // LDI A,(HL) -- Put value at address HL into A. Increment HL.
 func ldiAHL(cpu *CPU) {
-	cpu.Registers.A = cpu.MMU.Read(cpu.Registers.HL())
+	cpu.Registers.A = cpu.read(cpu.Registers.HL())
 	cpu.Registers.SetHL(cpu.Registers.HL() + 1)
 }
 
@@ -529,23 +546,25 @@ func ldHLSPPlusN(cpu *CPU, n int8) {
 // LD (nn),SP -- Put Stack Pointer (SP) at address n.
 func ldNNSP(cpu *CPU) {
 	address := cpu.Fetch16()
-	cpu.MMU.Write(address, byte(cpu.SP&0xff))
-	cpu.MMU.Write(address+1, byte(cpu.SP>>8))
+	cpu.write(address, byte(cpu.SP&0xff))
+	cpu.write(address+1, byte(cpu.SP>>8))
 }
 
 // PUSH nn -- Push register pair nn onto stack.
 // Decrement Stack Pointer (SP) twice
 func pushNN(cpu *CPU, address uint16) {
-	cpu.MMU.Write(cpu.SP-1, byte(uint16(address&0xff00)>>8))
-	cpu.MMU.Write(cpu.SP-2, byte(address&0xff))
+	// Stack pointer is decremented during an internal cycle before the writes
+	cpu.tick()
+	cpu.write(cpu.SP-1, byte(uint16(address&0xff00)>>8))
+	cpu.write(cpu.SP-2, byte(address&0xff))
 	cpu.SP -= 2
 }
 
 // POP nn --  Pop two bytes off stack into register pair nn.
 // Increment Stack Pointer (SP) twice.
 func popNN(cpu *CPU) uint16 {
-	byte1 := uint16(cpu.MMU.Read(cpu.SP))
-	byte2 := uint16(cpu.MMU.Read(cpu.SP+1)) << 8
+	byte1 := uint16(cpu.read(cpu.SP))
+	byte2 := uint16(cpu.read(cpu.SP+1)) << 8
 	cpu.SP += 2
 	return byte1 | byte2
 }
@@ -674,16 +693,38 @@ func halt(cpu *CPU) {
 	}
 }
 
-// STOP -- Halt CPU & LCD display until button pressed.
+// STOP -- Halt CPU and LCD until a button is pressed. On CGB, CPU speed is switched
+// instead if the switch has been armed through KEY1.
 func stop(cpu *CPU) {
+	// STOP is followed by a byte which is skipped
+	cpu.PC++
+	// DIV is reset when entering stop mode and during speed switch
+	cpu.MMU.Write(0xff04, 0)
+
+	if cpu.MMU.Model.IsCGB() && cpu.MMU.Read(0xff4d)&0x01 != 0 {
+		cpu.DoubleSpeed = !cpu.DoubleSpeed
+		key1 := byte(0x7e)
+		if cpu.DoubleSpeed {
+			key1 |= 0x80
+		}
+		cpu.MMU.Memory[0xff4d] = key1
+		return
+	}
 	cpu.Stopped = true
 }
 
+// DI -- Disable interrupts immediately, cancelling pending EI.
+func di(cpu *CPU) {
+	cpu.Interrupts.Disable()
+	cpu.enablingInterrupts = false
+}
+
 /* Jumps */
 
 // JP cc,nn -- Jump to address n if condition is true.
 func jpCC(cpu *CPU, condition bool, n uint16) {
 	if condition {
+		cpu.branchTaken = true
 		cpu.PC = n
 	}
 }
@@ -697,6 +738,7 @@ func jr(cpu *CPU, n int8) {
 // JR cc,nn -- If  condition is true then add n to current address and jump to it.
 func jrCC(cpu *CPU, condition bool, n int8) {
 	if condition {
+		cpu.branchTaken = true
 		address := int32(cpu.PC) + int32(n)
 		cpu.PC = uint16(address)
 	}
@@ -713,6 +755,7 @@ func call(cpu *CPU, next uint16) {
 // CALL cc,nn -- Call address n if condition is true.
 func callCC(cpu *CPU, condition bool, next uint16) {
 	if condition {
+		cpu.branchTaken = true
 		call(cpu, next)
 	}
 }
@@ -730,7 +773,10 @@ func rst(cpu *CPU, n byte) {
 
 // RET cc -- Return if condition is true.
 func retCC(cpu *CPU, condition bool) {
+	// Condition is evaluated during an internal cycle
+	cpu.tick()
 	if condition {
+		cpu.branchTaken = true
 		cpu.PC = popNN(cpu)
 	}
 }
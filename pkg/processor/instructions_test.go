@@ -0,0 +1,166 @@
+package processor
+
+import "testing"
+
+func TestConditionalBranchCycles(t *testing.T) {
+	tests := []struct {
+		name     string
+		program  []byte
+		notTaken int
+		taken    int
+	}{
+		{"JR NZ,n", []byte{0x20, 0x02}, 8, 12},
+		{"JP NZ,nn", []byte{0xc2, 0x00, 0xd0}, 12, 16},
+		{"CALL NZ,nn", []byte{0xc4, 0x00, 0xd0}, 12, 24},
+		{"RET NZ", []byte{0xc0}, 8, 20},
+	}
+	for _, test := range tests {
+		for _, taken := range []bool{false, true} {
+			cpu := NewCPU()
+			copy(cpu.MMU.Memory[0xc000:], test.program)
+			cpu.PC = 0xc000
+			cpu.SP = 0xdff0
+			cpu.SetZero(!taken)
+
+			expected := test.notTaken
+			if taken {
+				expected = test.taken
+			}
+			if cycles := cpu.Execute(); cycles != expected {
+				t.Errorf("%s should take %d cycles when taken is %v, got %d", test.name, expected, taken, cycles)
+			}
+		}
+	}
+}
+
+func TestUnconditionalBranchCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []byte
+		cycles  int
+	}{
+		{"JR n", []byte{0x18, 0x02}, 12},
+		{"JP nn", []byte{0xc3, 0x00, 0xd0}, 16},
+		{"CALL nn", []byte{0xcd, 0x00, 0xd0}, 24},
+		{"RET", []byte{0xc9}, 16},
+		{"RST 38H", []byte{0xff}, 16},
+	}
+	for _, test := range tests {
+		cpu := NewCPU()
+		copy(cpu.MMU.Memory[0xc000:], test.program)
+		cpu.PC = 0xc000
+		cpu.SP = 0xdff0
+		if cycles := cpu.Execute(); cycles != test.cycles {
+			t.Errorf("%s should take %d cycles, got %d", test.name, test.cycles, cycles)
+		}
+	}
+}
+
+// newTimingTestCPU creates CPU executing given program from work RAM, with registers
+// pointing to work RAM and high RAM so that memory accesses don't reach IO registers
+// other than timer.
+func newTimingTestCPU(program []byte) (*CPU, *int) {
+	cpu := NewCPU()
+	copy(cpu.MMU.Memory[0xc000:], program)
+	cpu.PC = 0xc000
+	cpu.SP = 0xdff0
+	cpu.Registers.SetBC(0xc880)
+	cpu.Registers.SetDE(0xc900)
+	cpu.Registers.SetHL(0xca00)
+	cpu.MMU.Map(0xff04, 0xff07, &accessRecorder{cpu: cpu})
+	ticks := new(int)
+	cpu.Tick = func(cycles int) { *ticks += cycles }
+	return cpu, ticks
+}
+
+func TestInstructionTicks(t *testing.T) {
+	for op := 0; op < 0x100; op++ {
+		if op == 0xcb {
+			continue
+		}
+		cpu, ticks := newTimingTestCPU([]byte{byte(op), 0x80, 0xd0})
+		if cycles := cpu.Execute(); cycles != *ticks {
+			t.Errorf("Instruction %02x should tick %d cycles it takes, got %d", op, cycles, *ticks)
+		}
+	}
+	for op := 0; op < 0x100; op++ {
+		cpu, ticks := newTimingTestCPU([]byte{0xcb, byte(op)})
+		if cycles := cpu.Execute(); cycles != *ticks {
+			t.Errorf("Instruction cb %02x should tick %d cycles it takes, got %d", op, cycles, *ticks)
+		}
+	}
+}
+
+// accessRecorder records machine cycles of instruction at which timer registers are accessed.
+type accessRecorder struct {
+	cpu      *CPU
+	accesses []int
+}
+
+func (rec *accessRecorder) Read(address uint16) byte {
+	rec.accesses = append(rec.accesses, rec.cpu.cycles)
+	return 0
+}
+
+func (rec *accessRecorder) Write(address uint16, value byte) {
+	rec.accesses = append(rec.accesses, rec.cpu.cycles)
+}
+
+func TestMemoryAccessTiming(t *testing.T) {
+	tests := []struct {
+		name     string
+		program  []byte
+		sp       uint16
+		accesses []int
+	}{
+		{"LD A,(HL)", []byte{0x7e}, 0xff06, []int{2}},
+		{"LD (HL),n", []byte{0x36, 0x00}, 0xff06, []int{3}},
+		{"INC (HL)", []byte{0x34}, 0xff06, []int{2, 3}},
+		{"LDH A,(n)", []byte{0xf0, 0x05}, 0xff06, []int{3}},
+		{"LD (nn),A", []byte{0xea, 0x05, 0xff}, 0xff06, []int{4}},
+		{"PUSH BC", []byte{0xc5}, 0xff06, []int{3, 4}},
+		{"CALL nn", []byte{0xcd, 0x00, 0xc1}, 0xff06, []int{5, 6}},
+		{"RST 38H", []byte{0xff}, 0xff06, []int{3, 4}},
+		{"POP BC", []byte{0xc1}, 0xff04, []int{2, 3}},
+		{"SET 0,(HL)", []byte{0xcb, 0xc6}, 0xff06, []int{3, 4}},
+	}
+	for _, test := range tests {
+		cpu, _ := newTimingTestCPU(test.program)
+		rec := &accessRecorder{cpu: cpu}
+		cpu.MMU.Map(0xff04, 0xff07, rec)
+		cpu.Registers.SetHL(0xff05)
+		cpu.SP = test.sp
+		cpu.Execute()
+
+		if len(rec.accesses) != len(test.accesses) {
+			t.Errorf("%s should access memory at cycles %v, got %v", test.name, test.accesses, rec.accesses)
+			continue
+		}
+		for i := range test.accesses {
+			if rec.accesses[i] != test.accesses[i] {
+				t.Errorf("%s should access memory at cycles %v, got %v", test.name, test.accesses, rec.accesses)
+				break
+			}
+		}
+	}
+}
+
+func TestIllegalOpcodeLocksCPU(t *testing.T) {
+	for _, op := range []byte{0xd3, 0xdb, 0xdd, 0xe3, 0xe4, 0xeb, 0xec, 0xed, 0xf4, 0xfc, 0xfd} {
+		cpu, _ := newTimingTestCPU([]byte{op})
+		if err := cpu.Err(); err != nil {
+			t.Fatalf("Running CPU should not report error, got %v", err)
+		}
+		cpu.Execute()
+
+		if !cpu.Locked {
+			t.Errorf("Opcode %02x should lock up CPU", op)
+		}
+		err, ok := cpu.Err().(*IllegalOpcodeError)
+		if !ok {
+			t.Errorf("Opcode %02x should report IllegalOpcodeError, got %v", op, cpu.Err())
+		} else if err.Opcode != op || err.Address != 0xc000 {
+			t.Errorf("Error should report opcode %02x at c000, got %02x at %04x", op, err.Opcode, err.Address)
+		}
+	}
+}
@@ -82,33 +82,53 @@ func (interrupts *Interrupts) SetInterrupt(flag InterruptFlag) {
 	interrupts.IF = utils.SetBit(interrupts.IF, flag)
 }
 
-// Resolve raised interrupts accordingly.
-func (interrupts *Interrupts) Resolve(cpu *CPU) {
-	if !interrupts.IME && !cpu.Halted {
-		return
+// Resolve raised interrupts accordingly and return the amount of cycles taken.
+// Pending interrupt wakes CPU from halt, and if interrupts are enabled, the one with
+// the highest priority is dispatched.
+func (interrupts *Interrupts) Resolve(cpu *CPU) int {
+	if interrupts.IE&interrupts.IF&0x1f == 0 {
+		return 0
 	}
-	for f := 0; f < 5; f++ {
-		if utils.TestBit(interrupts.IE, f) && utils.TestBit(interrupts.IF, f) {
-			interrupts.resolveInterrupt(f, cpu)
-		}
+	// If interrupts are disabled, CPU continues executing instructions after halt
+	// without jumping to interrupt vector.
+	if !interrupts.IME {
+		cpu.Halted = false
+		return 0
 	}
-}
 
-// resolveInterrupt is a helper function for resolving a specific interrupt.
-func (interrupts *Interrupts) resolveInterrupt(flag int, cpu *CPU) {
-	// If interrupts are pending, IME is false and halt has been called,
-	// cpu continues executing instructions without halting or jumping to interrupt vector.
-	if !interrupts.IME && cpu.Halted {
+	cycles := 0
+	if cpu.Halted {
+		// Exiting halt mode takes an extra cycle before dispatch
 		cpu.Halted = false
-		return
+		cpu.tick()
+		cycles += 4
 	}
+	return cycles + interrupts.dispatch(cpu)
+}
 
-	// Reset interrupt
-	interrupts.IF = utils.ResetBit(interrupts.IF, flag)
+// dispatch pushes PC to stack and jumps to the vector of highest priority pending interrupt.
+// Dispatch takes 5 machine cycles: two wait states, two writes and setting PC.
+func (interrupts *Interrupts) dispatch(cpu *CPU) int {
 	interrupts.Disable()
-	cpu.Halted = false
-
-	// Push pc to stack and jump to interrupt address
-	cpu.pushPC()
-	cpu.PC = irqAddresses[flag]
+	cpu.tick()
+	cpu.tick()
+
+	cpu.SP--
+	cpu.write(cpu.SP, byte(cpu.PC>>8))
+	// Interrupt is selected only after the high byte is pushed, so if the push
+	// overwrote IE, a different interrupt is dispatched or the dispatch is cancelled.
+	pending := interrupts.IE & interrupts.IF & 0x1f
+	cpu.SP--
+	cpu.write(cpu.SP, byte(cpu.PC))
+
+	cpu.PC = 0x0000
+	for f := 0; f < 5; f++ {
+		if utils.TestBit(pending, f) {
+			interrupts.IF = utils.ResetBit(interrupts.IF, f)
+			cpu.PC = irqAddresses[f]
+			break
+		}
+	}
+	cpu.tick()
+	return 5 * 4
 }
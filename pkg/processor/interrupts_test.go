@@ -0,0 +1,118 @@
+package processor
+
+import "testing"
+
+// newInterruptTestCPU creates CPU with interrupts enabled and given interrupts pending.
+func newInterruptTestCPU(ie, iflag byte) (*CPU, *int) {
+	cpu := NewCPU()
+	cpu.MMU.Map(0xffff, 0xffff, cpu.Interrupts)
+	cpu.PC = 0x1234
+	cpu.SP = 0xdff0
+	cpu.Interrupts.IME = true
+	cpu.Interrupts.IE = ie
+	cpu.Interrupts.IF = iflag
+	ticks := new(int)
+	cpu.Tick = func(cycles int) { *ticks += cycles }
+	return cpu, ticks
+}
+
+func TestInterruptPriority(t *testing.T) {
+	cpu, _ := newInterruptTestCPU(0x1f, 0x1c)
+	cpu.Interrupts.Resolve(cpu)
+
+	if cpu.PC != 0x50 {
+		t.Errorf("Timer interrupt should be dispatched first, got PC %x", cpu.PC)
+	}
+	if cpu.Interrupts.IF != 0x18 {
+		t.Errorf("Only dispatched interrupt should be cleared, got IF %x", cpu.Interrupts.IF)
+	}
+	if cpu.SP != 0xdfee {
+		t.Errorf("PC should be pushed once, got SP %x", cpu.SP)
+	}
+	if cpu.Interrupts.IME {
+		t.Errorf("Interrupts should be disabled after dispatch")
+	}
+	if value := uint16(cpu.MMU.Read(0xdfef))<<8 | uint16(cpu.MMU.Read(0xdfee)); value != 0x1234 {
+		t.Errorf("Return address should be pushed to stack, got %x", value)
+	}
+}
+
+func TestInterruptDispatchCycles(t *testing.T) {
+	cpu, ticks := newInterruptTestCPU(0x01, 0x01)
+	if cycles := cpu.Interrupts.Resolve(cpu); cycles != 20 || *ticks != 20 {
+		t.Errorf("Dispatch should take 20 cycles, took %d and ticked %d", cycles, *ticks)
+	}
+
+	cpu, ticks = newInterruptTestCPU(0x01, 0x01)
+	cpu.Halted = true
+	if cycles := cpu.Interrupts.Resolve(cpu); cycles != 24 || *ticks != 24 {
+		t.Errorf("Dispatch from halt should take 24 cycles, took %d and ticked %d", cycles, *ticks)
+	}
+	if cpu.Halted {
+		t.Errorf("Interrupt should wake CPU from halt")
+	}
+
+	cpu, ticks = newInterruptTestCPU(0x01, 0x02)
+	if cycles := cpu.Interrupts.Resolve(cpu); cycles != 0 || *ticks != 0 || cpu.PC != 0x1234 {
+		t.Errorf("Disabled interrupt should not be dispatched")
+	}
+}
+
+func TestHaltWithInterruptsDisabled(t *testing.T) {
+	cpu, ticks := newInterruptTestCPU(0x04, 0x04)
+	cpu.Interrupts.IME = false
+	cpu.Halted = true
+
+	if cycles := cpu.Interrupts.Resolve(cpu); cycles != 0 || *ticks != 0 {
+		t.Errorf("Waking from halt without dispatch should not take cycles, took %d", cycles)
+	}
+	if cpu.Halted || cpu.PC != 0x1234 || cpu.Interrupts.IF != 0x04 {
+		t.Errorf("CPU should continue after halt without servicing interrupt, got PC %x IF %x", cpu.PC, cpu.Interrupts.IF)
+	}
+}
+
+func TestInterruptCancelledByIEPush(t *testing.T) {
+	tests := []struct {
+		pc       uint16
+		ie       byte
+		expected uint16
+	}{
+		// High byte of PC is pushed to IE, leaving no interrupt to dispatch
+		{0x0200, 0x02, 0x0000},
+		// High byte keeps VBlank enabled
+		{0x0100, 0x01, 0x0040},
+		// High byte enables a different pending interrupt
+		{0x0400, 0x04, 0x0050},
+	}
+	for _, test := range tests {
+		cpu, _ := newInterruptTestCPU(0x01, 0x05)
+		cpu.PC = test.pc
+		cpu.SP = 0x0000
+		cpu.Interrupts.Resolve(cpu)
+
+		if cpu.PC != test.expected {
+			t.Errorf("Pushing %x to IE should dispatch to %x, got PC %x", test.pc>>8, test.expected, cpu.PC)
+		}
+		if cpu.Interrupts.IE != test.ie {
+			t.Errorf("High byte of PC should be written to IE, got %x", cpu.Interrupts.IE)
+		}
+	}
+}
+
+func TestDisableInterruptsImmediately(t *testing.T) {
+	cpu, _ := newTimingTestCPU([]byte{0xfb, 0xf3, 0x00})
+	cpu.Interrupts.IME = false
+
+	cpu.Execute() // EI
+	cpu.Execute() // DI
+	if cpu.Interrupts.IME {
+		t.Errorf("DI should cancel pending EI")
+	}
+
+	cpu.Interrupts.IME = true
+	cpu.PC = 0xc001
+	cpu.Execute() // DI
+	if cpu.Interrupts.IME {
+		t.Errorf("DI should disable interrupts immediately")
+	}
+}